@@ -1,18 +1,140 @@
 package app
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
 	"github.com/leandrotula/golangmicroservice/controllers"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/services"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
 )
 
-var ginHttp = gin.Default()
+const (
+	userStoreKey         = "USER_STORE"
+	userStoreLocationKey = "USER_STORE_PATH"
+	jwtSecretKey         = "JWT_SECRET"
+	bootstrapEmailKey    = "BOOTSTRAP_EMAIL"
+	bootstrapPasswordKey = "BOOTSTRAP_PASSWORD"
+	cacheSizeKey         = "USER_CACHE_SIZE"
+	cacheTTLKey          = "USER_CACHE_TTL"
+	cacheNegativeTTLKey  = "USER_CACHE_NEGATIVE_TTL"
 
-func StartApp() {
+	defaultCacheSize        = 1000
+	defaultCacheTTL         = 30 * time.Second
+	defaultCacheNegativeTTL = 5 * time.Second
+)
+
+var userPolicy = authorization.Policy{
+	authorization.Route(http.MethodGet, "/user/:id"):                authorization.ReadUsers,
+	authorization.Route(http.MethodPost, "/user"):                   authorization.WriteUsers,
+	authorization.Route(http.MethodPut, "/user/:id"):                authorization.WriteUsers,
+	authorization.Route(http.MethodPatch, "/user/:id"):              authorization.WriteUsers,
+	authorization.Route(http.MethodDelete, "/user/:id"):             authorization.WriteUsers,
+	authorization.Route(http.MethodPost, "/user/:id/restore"):       authorization.WriteUsers,
+	authorization.Route(http.MethodGet, "/user/:id/repositories"):   authorization.ReadUsers,
+	authorization.Route(http.MethodGet, "/user/:id/history"):        authorization.ReadUsers,
+	authorization.Route(http.MethodGet, "/users"):                   authorization.ReadUsers,
+	authorization.Route(http.MethodGet, "/users/search"):            authorization.ReadUsers,
+	authorization.Route(http.MethodPost, "/users/import"):           authorization.WriteUsers,
+	authorization.Route(http.MethodGet, "/users/export"):            authorization.ReadUsers,
+	authorization.Route(http.MethodGet, "/admin/cache/users"):       authorization.ReadStats,
+	authorization.Route(http.MethodPost, "/graphql"):                authorization.ReadUsers,
+	authorization.Route(http.MethodPost, "/api-keys"):               authorization.ManageApiKeys,
+	authorization.Route(http.MethodGet, "/api-keys"):                authorization.ManageApiKeys,
+	authorization.Route(http.MethodDelete, "/api-keys/:id"):         authorization.ManageApiKeys,
+	authorization.Route(http.MethodPost, "/webhooks"):               authorization.ManageWebhooks,
+	authorization.Route(http.MethodGet, "/webhooks"):                authorization.ManageWebhooks,
+	authorization.Route(http.MethodDelete, "/webhooks/:id"):         authorization.ManageWebhooks,
+	authorization.Route(http.MethodGet, "/webhooks/:id/deliveries"): authorization.ManageWebhooks,
+}
 
-	ginHttp.GET("/user/:id", controllers.GetUser)
+// Configure prepares the stores and the services behind the user api, it runs once at startup.
+func Configure() {
 
-	if err := ginHttp.Run(":8081"); err != nil {
+	if err := domain.ConfigureUserDao(os.Getenv(userStoreKey), os.Getenv(userStoreLocationKey)); err != nil {
 		panic(err)
 	}
 
+	domain.ConfigureUserCache(
+		intSetting(cacheSizeKey, defaultCacheSize),
+		durationSetting(cacheTTLKey, defaultCacheTTL),
+		durationSetting(cacheNegativeTTLKey, defaultCacheNegativeTTL))
+
+	services.ConfigureAuth(os.Getenv(jwtSecretKey))
+
+	if email := os.Getenv(bootstrapEmailKey); email != "" {
+		bootstrap := domain.User{Email: email, Password: os.Getenv(bootstrapPasswordKey), Role: domain.RoleAdmin}
+		if err := services.BootstrapUser(bootstrap); err != nil {
+			panic(err.Message)
+		}
+	}
+
+	services.StartWebhookDispatcher()
+}
+
+// MapUrls registers the user api under router, whatever prefix the router group has.
+func MapUrls(router *gin.RouterGroup) {
+
+	router.POST("/auth/login", controllers.Login)
+	router.POST("/auth/refresh", controllers.RefreshToken)
+	router.POST("/auth/revoke", controllers.RevokeToken)
+
+	authenticated := router.Group("", controllers.Authenticate,
+		authorization.Authorize(userPolicy.Prefixed(router.BasePath()), controllers.Forbidden))
+
+	authenticated.GET("/user/:id", controllers.GetUser)
+	authenticated.POST("/user", controllers.CreateUser)
+	authenticated.PUT("/user/:id", controllers.UpdateUser)
+	authenticated.PATCH("/user/:id", controllers.UpdateUser)
+	authenticated.DELETE("/user/:id", controllers.DeleteUser)
+	authenticated.POST("/user/:id/restore", controllers.RestoreUser)
+	authenticated.GET("/user/:id/history", controllers.GetUserHistory)
+	authenticated.GET("/user/:id/repositories", controllers.GetUserRepositories)
+	authenticated.GET("/users", controllers.ListUsers)
+	authenticated.GET("/users/search", controllers.SearchUsers)
+	authenticated.POST("/users/import", controllers.ImportUsers)
+	authenticated.GET("/users/export", controllers.ExportUsers)
+	authenticated.GET("/admin/cache/users", controllers.GetUserCacheStats)
+	authenticated.POST("/graphql", controllers.GraphQL)
+	authenticated.POST("/api-keys", controllers.CreateApiKey)
+	authenticated.GET("/api-keys", controllers.ListApiKeys)
+	authenticated.DELETE("/api-keys/:id", controllers.RevokeApiKey)
+	authenticated.POST("/webhooks", controllers.CreateWebhook)
+	authenticated.GET("/webhooks", controllers.ListWebhooks)
+	authenticated.DELETE("/webhooks/:id", controllers.DeleteWebhook)
+	authenticated.GET("/webhooks/:id/deliveries", controllers.GetWebhookDeliveries)
+}
+
+func intSetting(key string, fallback int) int {
+
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %s", key, value))
+	}
+
+	return parsed
+}
+
+func durationSetting(key string, fallback time.Duration) time.Duration {
+
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %s", key, value))
+	}
+
+	return parsed
 }
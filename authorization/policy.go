@@ -0,0 +1,138 @@
+package authorization
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"strings"
+)
+
+const (
+	// RoleKey is the gin context key the authentication middleware stores the caller role under.
+	RoleKey = "auth_role"
+	// ScopesKey holds the scopes of the api key of the caller, when it authenticated with one.
+	ScopesKey = "auth_scopes"
+	// UserIdKey and ApiKeyIdKey identify the caller, only one of them is set.
+	UserIdKey   = "auth_user_id"
+	ApiKeyIdKey = "auth_api_key_id"
+)
+
+type Permission string
+
+const (
+	ReadUsers          Permission = "users:read"
+	WriteUsers         Permission = "users:write"
+	ManageRoles        Permission = "roles:manage"
+	ManageApiKeys      Permission = "api_keys:manage"
+	ManageWebhooks     Permission = "webhooks:manage"
+	ReadStats          Permission = "stats:read"
+	CreateRepositories Permission = "repositories:create"
+	DeleteRepositories Permission = "repositories:delete"
+	// ManageRepositories lets a caller delete repositories that were not created through the api.
+	ManageRepositories Permission = "repositories:manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	domain.RoleViewer:   {ReadUsers},
+	domain.RoleOperator: {ReadUsers, WriteUsers, CreateRepositories, DeleteRepositories},
+	domain.RoleAdmin: {ReadUsers, WriteUsers, ManageRoles, ManageApiKeys, ManageWebhooks, ReadStats,
+		CreateRepositories, DeleteRepositories, ManageRepositories},
+}
+
+// IsPermission tells whether name is a known permission, which is what api key scopes hold.
+func IsPermission(name string) bool {
+
+	for _, permissions := range rolePermissions {
+		for _, permission := range permissions {
+			if string(permission) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Policy maps "METHOD /route/:param" (the route as registered in gin) to the permission it requires.
+type Policy map[string]Permission
+
+func Route(method string, path string) string {
+
+	return fmt.Sprintf("%s %s", method, path)
+}
+
+// Prefixed returns the policy for the same routes mounted under prefix.
+func (p Policy) Prefixed(prefix string) Policy {
+
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return p
+	}
+
+	prefixed := make(Policy, len(p))
+	for route, permission := range p {
+		parts := strings.SplitN(route, " ", 2)
+		prefixed[Route(parts[0], prefix+parts[1])] = permission
+	}
+
+	return prefixed
+}
+
+// HasPermission tells whether role grants permission, users without a role are viewers.
+func HasPermission(role string, permission Permission) bool {
+
+	if role == "" {
+		role = domain.DefaultRole
+	}
+
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CallerHasPermission checks the role the authentication middleware left in the context, or
+// the scopes of the api key for callers authenticated with one.
+func CallerHasPermission(c *gin.Context, permission Permission) bool {
+
+	if scopes, present := c.Get(ScopesKey); present {
+		return ScopesGrant(scopes.([]string), permission)
+	}
+
+	return HasPermission(c.GetString(RoleKey), permission)
+}
+
+// ScopesGrant tells whether the scopes of an api key include permission.
+func ScopesGrant(scopes []string, permission Permission) bool {
+
+	for _, scope := range scopes {
+		if scope == string(permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authorize must run after authentication. Routes missing from the policy are denied, so a new
+// route cannot be exposed by forgetting to add it. forbidden writes the 403 in the error format
+// of the routes being protected.
+func Authorize(policy Policy, forbidden gin.HandlerFunc) gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+
+		permission, present := policy[Route(c.Request.Method, c.FullPath())]
+
+		if !present || !CallerHasPermission(c, permission) {
+
+			forbidden(c)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package authorization
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHasPermission(t *testing.T) {
+
+	assert.True(t, HasPermission(domain.RoleViewer, ReadUsers))
+	assert.False(t, HasPermission(domain.RoleViewer, WriteUsers))
+	assert.True(t, HasPermission(domain.RoleOperator, CreateRepositories))
+	assert.False(t, HasPermission(domain.RoleOperator, ManageRoles))
+	assert.True(t, HasPermission(domain.RoleAdmin, ManageRoles))
+	assert.True(t, HasPermission(domain.RoleOperator, DeleteRepositories))
+	assert.False(t, HasPermission(domain.RoleOperator, ManageRepositories))
+	assert.True(t, HasPermission("", ReadUsers))
+	assert.False(t, HasPermission("unknown", ReadUsers))
+}
+
+func serve(role string, method string, path string) *httptest.ResponseRecorder {
+
+	policy := Policy{Route(http.MethodPost, "/user"): WriteUsers}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set(RoleKey, role) }, Authorize(policy, func(c *gin.Context) {
+		c.JSON(http.StatusForbidden, "forbidden")
+	}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.POST("/user", ok)
+	router.GET("/user/:id", ok)
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest(method, path, nil)
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestAuthorize(t *testing.T) {
+
+	assert.EqualValues(t, http.StatusOK, serve(domain.RoleOperator, http.MethodPost, "/user").Code)
+	assert.EqualValues(t, http.StatusForbidden, serve(domain.RoleViewer, http.MethodPost, "/user").Code)
+	// routes missing from the policy are denied
+	assert.EqualValues(t, http.StatusForbidden, serve(domain.RoleAdmin, http.MethodGet, "/user/1").Code)
+}
+
+func TestCallerHasPermissionWithApiKeyScopes(t *testing.T) {
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(RoleKey, domain.RoleAdmin)
+	c.Set(ScopesKey, []string{string(CreateRepositories)})
+
+	assert.True(t, CallerHasPermission(c, CreateRepositories))
+	assert.False(t, CallerHasPermission(c, ReadUsers))
+}
+
+func TestPrefixedPolicy(t *testing.T) {
+
+	policy := Policy{Route(http.MethodPost, "/user"): WriteUsers}
+
+	assert.Equal(t, policy, policy.Prefixed("/"))
+	assert.Equal(t, Policy{Route(http.MethodPost, "/api/users/user"): WriteUsers}, policy.Prefixed("/api/users/"))
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/services"
+	"net/http"
+)
+
+func GetUserCacheStats(c *gin.Context) {
+
+	stats, err := services.GetUserCacheStats()
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// AuthenticateService accepts an api key in place of a bearer token, for callers that are not
+// users. The scopes of the key replace the permissions of a role.
+func AuthenticateService(c *gin.Context) {
+
+	key := c.GetHeader(apiKeyHeader)
+	if key == "" {
+		Authenticate(c)
+		return
+	}
+
+	apiKey, err := services.AuthenticateApiKey(key)
+
+	if err != nil {
+
+		c.AbortWithStatusJSON(err.Code, err)
+		return
+	}
+
+	c.Set(authorization.ScopesKey, apiKey.Scopes)
+	c.Set(authorization.ApiKeyIdKey, int64(apiKey.Id))
+	c.Next()
+}
+
+func CreateApiKey(c *gin.Context) {
+
+	var request domain.ApiKeyRequest
+	if bindError := c.ShouldBindJSON(&request); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
+
+		c.JSON(responseError.Code, responseError)
+		return
+	}
+
+	created, err := services.CreateApiKey(request, actor(c))
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func ListApiKeys(c *gin.Context) {
+
+	keys, err := services.ListApiKeys()
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+func RevokeApiKey(c *gin.Context) {
+
+	keyId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	if _, err := services.RevokeApiKey(keyId); err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
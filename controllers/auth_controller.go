@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"strings"
+)
+
+const claimsKey = "auth_claims"
+
+// Forbidden is how the user api answers callers without the permission a route requires.
+func Forbidden(c *gin.Context) {
+
+	responseError := util.ResponseError{
+		Code:    http.StatusForbidden,
+		Message: "forbidden",
+	}
+
+	c.JSON(responseError.Code, responseError)
+}
+
+// Authenticate rejects the request unless it carries a valid access token as a bearer token.
+func Authenticate(c *gin.Context) {
+
+	header := c.GetHeader("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusUnauthorized,
+			Message: "missing bearer token",
+		}
+
+		c.Header("WWW-Authenticate", "Bearer")
+		c.AbortWithStatusJSON(responseError.Code, responseError)
+		return
+	}
+
+	claims, err := services.AuthService.ValidateAccessToken(strings.TrimPrefix(header, "Bearer "))
+
+	if err != nil {
+
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		c.AbortWithStatusJSON(err.Code, err)
+		return
+	}
+
+	c.Set(claimsKey, claims)
+	c.Set(authorization.RoleKey, claims.Role)
+	c.Set(authorization.UserIdKey, claims.UserId())
+	c.Next()
+}
+
+func authClaims(c *gin.Context) *services.TokenClaims {
+
+	if value, present := c.Get(claimsKey); present {
+		return value.(*services.TokenClaims)
+	}
+
+	return nil
+}
+
+func Login(c *gin.Context) {
+
+	var credentials domain.Credentials
+	if bindError := c.ShouldBindJSON(&credentials); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
+
+		c.JSON(responseError.Code, responseError)
+		return
+	}
+
+	tokens, err := services.AuthService.Login(credentials)
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func RefreshToken(c *gin.Context) {
+
+	var request domain.RefreshRequest
+	if bindError := c.ShouldBindJSON(&request); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
+
+		c.JSON(responseError.Code, responseError)
+		return
+	}
+
+	tokens, err := services.AuthService.Refresh(request.RefreshToken)
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func RevokeToken(c *gin.Context) {
+
+	var request domain.RefreshRequest
+	if bindError := c.ShouldBindJSON(&request); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
+
+		c.JSON(responseError.Code, responseError)
+		return
+	}
+
+	if err := services.AuthService.Revoke(request.RefreshToken); err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
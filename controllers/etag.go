@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func userETag(user *domain.User) string {
+
+	return fmt.Sprintf(`"%d"`, user.Version)
+}
+
+// ifMatchVersion reads the version the client expects from the mandatory If-Match header.
+// "*" matches any version and is returned as zero.
+func ifMatchVersion(c *gin.Context) (uint64, *util.ResponseError) {
+
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+
+	if header == "" {
+		return 0, &util.ResponseError{
+			Code:    http.StatusPreconditionRequired,
+			Message: "If-Match header is required",
+		}
+	}
+
+	if header == "*" {
+		return 0, nil
+	}
+
+	version, parserError := strconv.ParseUint(strings.Trim(header, `"`), 10, 64)
+	if parserError != nil || version == 0 {
+		return 0, &util.ResponseError{
+			Code:    http.StatusPreconditionFailed,
+			Message: "invalid If-Match header",
+		}
+	}
+
+	return version, nil
+}
+
+// noneMatch reports whether any of the If-None-Match tags is the current one.
+func noneMatch(c *gin.Context, etag string) bool {
+
+	for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/graph"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+)
+
+func GraphQL(c *gin.Context) {
+
+	var request graph.Request
+	if bindError := c.ShouldBindJSON(&request); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
+
+		c.JSON(responseError.Code, responseError)
+		return
+	}
+
+	result, status := graph.Execute(c.Request.Context(), request)
+	c.JSON(status, result)
+}
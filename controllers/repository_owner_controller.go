@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/services"
+	"net/http"
+)
+
+func GetUserRepositories(c *gin.Context) {
+
+	userId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	repositories, err := services.GetUserRepositories(userId)
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, repositories)
+}
@@ -2,30 +2,107 @@ package controllers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/problem"
 	"github.com/leandrotula/golangmicroservice/services"
 	"github.com/leandrotula/golangmicroservice/util"
 	"net/http"
 	"strconv"
 )
 
-func GetUser(c *gin.Context) {
+const (
+	actorHeader    = "X-Actor"
+	anonymousActor = "anonymous"
+)
+
+// actor identifies who performs a change, for the history of the user. The authenticated user
+// always wins over the header, which is only meant for unauthenticated tooling.
+func actor(c *gin.Context) string {
+
+	if claims := authClaims(c); claims != nil {
+		return claims.Email
+	}
+
+	if name := c.GetHeader(actorHeader); name != "" {
+		return name
+	}
 
-	id := c.Param("id")
+	return anonymousActor
+}
+
+// getIdParam parses the numeric :id of the route, whatever resource it names.
+func getIdParam(c *gin.Context) (int64, *util.ResponseError) {
 
-	userId, parserError := strconv.ParseInt(id, 10, 64)
+	id, parserError := strconv.ParseInt(c.Param("id"), 10, 64)
 
 	if parserError != nil {
 
-		responseError := util.ResponseError{
+		return 0, &util.ResponseError{
 			Code:    http.StatusBadRequest,
 			Message: "Could not convert to desired id type",
 		}
+	}
+
+	return id, nil
+}
+
+func GetUser(c *gin.Context) {
+
+	userId, idError := getIdParam(c)
+	if idError != nil {
+
+		problem.WriteResponseError(c, idError)
+		return
+	}
+
+	getUser := services.GetUser
+	if c.Query("include_deleted") == "true" {
+		getUser = services.GetUserIncludingDeleted
+	}
+
+	user, err := getUser(userId)
+
+	if err != nil {
+
+		problem.WriteResponseError(c, err)
+		return
+	}
+
+	etag := userETag(user)
+	c.Header("ETag", etag)
+
+	if noneMatch(c, etag) {
+
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+
+}
+
+func CreateUser(c *gin.Context) {
+
+	var user domain.User
+	if bindError := c.ShouldBindJSON(&user); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
 
 		c.JSON(responseError.Code, responseError)
 		return
 	}
 
-	user, err := services.GetUser(userId)
+	if roleError := checkRoleAssignment(c, user); roleError != nil {
+
+		c.JSON(roleError.Code, roleError)
+		return
+	}
+
+	created, err := services.CreateUser(user, actor(c))
 
 	if err != nil {
 
@@ -33,6 +110,315 @@ func GetUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.Header("ETag", userETag(created))
+	c.JSON(http.StatusCreated, created)
+}
+
+func UpdateUser(c *gin.Context) {
+
+	userId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	version, versionError := ifMatchVersion(c)
+	if versionError != nil {
+
+		c.JSON(versionError.Code, versionError)
+		return
+	}
+
+	var user domain.User
+	if bindError := c.ShouldBindJSON(&user); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
+
+		c.JSON(responseError.Code, responseError)
+		return
+	}
+
+	if roleError := checkRoleAssignment(c, user); roleError != nil {
+
+		c.JSON(roleError.Code, roleError)
+		return
+	}
+
+	if writeError := checkUserWrite(c, userId, &user); writeError != nil {
+
+		c.JSON(writeError.Code, writeError)
+		return
+	}
+
+	user.Id = uint64(userId)
+	user.Version = version
+	isPartial := c.Request.Method == http.MethodPatch
+
+	updated, err := services.UpdateUser(isPartial, user, actor(c))
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.Header("ETag", userETag(updated))
+	c.JSON(http.StatusOK, updated)
+}
+
+func DeleteUser(c *gin.Context) {
+
+	userId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	version, versionError := ifMatchVersion(c)
+	if versionError != nil {
+
+		c.JSON(versionError.Code, versionError)
+		return
+	}
+
+	if writeError := checkUserWrite(c, userId, nil); writeError != nil {
+
+		c.JSON(writeError.Code, writeError)
+		return
+	}
+
+	if err := services.DeleteUser(userId, version, actor(c)); err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func ListUsers(c *gin.Context) {
+
+	query := domain.UserQuery{
+		IncludeDeleted: c.Query("include_deleted") == "true",
+		FirstName:      c.Query("first_name"),
+		LastName:       c.Query("last_name"),
+		Email:          c.Query("email"),
+		Sort:           c.Query("sort"),
+		Cursor:         c.Query("cursor"),
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+
+		parsedLimit, parserError := strconv.Atoi(limit)
+		if parserError != nil {
+
+			responseError := util.ResponseError{
+				Code:    http.StatusBadRequest,
+				Message: "invalid limit",
+			}
+
+			c.JSON(responseError.Code, responseError)
+			return
+		}
+		query.Limit = parsedLimit
+	}
+
+	users, nextCursor, err := services.ListUsers(query)
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	response := domain.UserList{Results: users}
+	if nextCursor != "" {
+
+		next := *c.Request.URL
+		values := next.Query()
+		values.Set("cursor", nextCursor)
+		next.RawQuery = values.Encode()
+		response.Next = next.RequestURI()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func RestoreUser(c *gin.Context) {
+
+	userId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	if writeError := checkUserWrite(c, userId, nil); writeError != nil {
+
+		c.JSON(writeError.Code, writeError)
+		return
+	}
+
+	restored, err := services.RestoreUser(userId, actor(c))
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.Header("ETag", userETag(restored))
+	c.JSON(http.StatusOK, restored)
+}
+
+func GetUserHistory(c *gin.Context) {
+
+	userId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	history, err := services.GetUserHistory(userId)
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func SearchUsers(c *gin.Context) {
+
+	var limit int
+	if value := c.Query("limit"); value != "" {
+
+		parsedLimit, parserError := strconv.Atoi(value)
+		if parserError != nil {
+
+			responseError := util.ResponseError{
+				Code:    http.StatusBadRequest,
+				Message: "invalid limit",
+			}
+
+			c.JSON(responseError.Code, responseError)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	matches, err := services.SearchUsers(c.Query("q"), limit)
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+func ImportUsers(c *gin.Context) {
+
+	dryRun := c.Query("dry_run") == "true"
+
+	report, err := services.ImportUsers(c.ContentType(), c.Request.Body, dryRun, actor(c))
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func ExportUsers(c *gin.Context) {
+
+	export, err := services.NewUserExport(c.Query("format"), c.Query("fields"), c.Query("include_deleted") == "true")
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType())
+	c.Status(http.StatusOK)
+
+	if exportError := export.WriteTo(c.Writer, c.Writer.Flush); exportError != nil {
+		// the status is already sent, the truncated body is all the client can get
+		_ = c.Error(exportError)
+	}
+}
+
+// checkRoleAssignment allows setting the role of a user only to callers able to manage roles.
+func checkRoleAssignment(c *gin.Context, user domain.User) *util.ResponseError {
+
+	if user.Role == "" || authorization.CallerHasPermission(c, authorization.ManageRoles) {
+		return nil
+	}
+
+	return &util.ResponseError{
+		Message: "not allowed to assign roles",
+		Code:    http.StatusForbidden,
+	}
+}
+
+// checkUserWrite keeps users:write from taking over accounts: admins can only be changed, deleted
+// or restored by callers able to manage roles, and the password or email of a user only by the user
+// itself or such callers. changes is nil for writes that do not touch the profile.
+func checkUserWrite(c *gin.Context, userId int64, changes *domain.User) *util.ResponseError {
+
+	if authorization.CallerHasPermission(c, authorization.ManageRoles) {
+		return nil
+	}
+
+	target, err := services.GetUserIncludingDeleted(userId)
+	if err != nil {
+		// the write itself reports a missing user
+		if err.Code == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if target.Role == domain.RoleAdmin {
+		return &util.ResponseError{
+			Message: "not allowed to change admins",
+			Code:    http.StatusForbidden,
+		}
+	}
+
+	if changes != nil && !isCaller(c, userId) && changesCredentials(target, changes) {
+		return &util.ResponseError{
+			Message: "not allowed to change the password or email of another user",
+			Code:    http.StatusForbidden,
+		}
+	}
+
+	return nil
+}
+
+func isCaller(c *gin.Context, userId int64) bool {
+
+	callerId, present := c.Get(authorization.UserIdKey)
+	return present && callerId.(int64) == userId
+}
+
+func changesCredentials(target *domain.User, changes *domain.User) bool {
+
+	if changes.Password != "" {
+		return true
+	}
 
+	return changes.Email != "" && domain.NormalizeEmail(changes.Email) != target.Email
 }
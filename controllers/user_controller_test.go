@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/problem"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newUserContext(method string, id string, body string) (*gin.Context, *httptest.ResponseRecorder) {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+
+	request, _ := http.NewRequest(method, "/user/"+id, strings.NewReader(body))
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: id}}
+
+	return c, response
+}
+
+func TestGetUserReturnsETag(t *testing.T) {
+
+	c, response := newUserContext(http.MethodGet, "1", "")
+
+	GetUser(c)
+
+	assert.EqualValues(t, http.StatusOK, response.Code)
+	assert.EqualValues(t, `"1"`, response.Header().Get("ETag"))
+}
+
+func TestGetUserNotModified(t *testing.T) {
+
+	c, response := newUserContext(http.MethodGet, "1", "")
+	c.Request.Header.Set("If-None-Match", `"7", "1"`)
+
+	GetUser(c)
+	c.Writer.WriteHeaderNow()
+
+	assert.EqualValues(t, http.StatusNotModified, response.Code)
+	assert.EqualValues(t, 0, response.Body.Len())
+}
+
+func TestUpdateUserRequiresIfMatch(t *testing.T) {
+
+	c, response := newUserContext(http.MethodPut, "1", `{"email":"test@domain.com"}`)
+
+	UpdateUser(c)
+
+	assert.EqualValues(t, http.StatusPreconditionRequired, response.Code)
+}
+
+func TestUpdateUserStaleIfMatch(t *testing.T) {
+
+	c, response := newUserContext(http.MethodPatch, "1", `{"first_name":"Stale"}`)
+	c.Request.Header.Set("If-Match", `"42"`)
+
+	UpdateUser(c)
+
+	assert.EqualValues(t, http.StatusPreconditionFailed, response.Code)
+}
+
+func TestDeleteUserRequiresIfMatch(t *testing.T) {
+
+	c, response := newUserContext(http.MethodDelete, "1", "")
+
+	DeleteUser(c)
+
+	assert.EqualValues(t, http.StatusPreconditionRequired, response.Code)
+}
+
+func TestAuthenticateRejectsMissingToken(t *testing.T) {
+
+	c, response := newUserContext(http.MethodGet, "1", "")
+
+	Authenticate(c)
+
+	assert.True(t, c.IsAborted())
+	assert.EqualValues(t, http.StatusUnauthorized, response.Code)
+	assert.EqualValues(t, "Bearer", response.Header().Get("WWW-Authenticate"))
+}
+
+func TestAuthenticateRejectsInvalidToken(t *testing.T) {
+
+	c, response := newUserContext(http.MethodGet, "1", "")
+	c.Request.Header.Set("Authorization", "Bearer not-a-token")
+
+	Authenticate(c)
+
+	assert.True(t, c.IsAborted())
+	assert.EqualValues(t, http.StatusUnauthorized, response.Code)
+}
+
+func TestUpdateUserRoleRequiresManageRoles(t *testing.T) {
+
+	c, response := newUserContext(http.MethodPatch, "1", `{"role":"admin"}`)
+	c.Request.Header.Set("If-Match", `"1"`)
+	c.Set(authorization.RoleKey, domain.RoleOperator)
+
+	UpdateUser(c)
+
+	assert.EqualValues(t, http.StatusForbidden, response.Code)
+}
+
+func TestAuthenticateServiceRejectsUnknownApiKey(t *testing.T) {
+
+	c, response := newUserContext(http.MethodPost, "", "")
+	c.Request.Header.Set("X-API-Key", "gmk_unknown")
+
+	AuthenticateService(c)
+
+	assert.True(t, c.IsAborted())
+	assert.EqualValues(t, http.StatusUnauthorized, response.Code)
+}
+
+func TestGetUserNotFoundIsProblem(t *testing.T) {
+
+	c, response := newUserContext(http.MethodGet, "999", "")
+
+	GetUser(c)
+
+	assert.EqualValues(t, http.StatusNotFound, response.Code)
+	assert.EqualValues(t, problem.ContentType, response.Header().Get("Content-Type"))
+
+	var body problem.Problem
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.EqualValues(t, problem.DefaultType, body.Type)
+	assert.EqualValues(t, "Not Found", body.Title)
+	assert.EqualValues(t, http.StatusNotFound, body.Status)
+	assert.EqualValues(t, "/user/999", body.Instance)
+}
+
+func createTestUser(t *testing.T, email string, role string) *domain.User {
+
+	created, err := domain.UserDao.CreateUser(&domain.User{FirstName: "Guarded", Email: email, Role: role}, "test")
+	assert.Nil(t, err)
+
+	return created
+}
+
+func TestOperatorCannotChangeAdmins(t *testing.T) {
+
+	admin := createTestUser(t, "guarded-admin@domain.com", domain.RoleAdmin)
+	id := strconv.FormatUint(admin.Id, 10)
+
+	c, response := newUserContext(http.MethodPatch, id, `{"password":"Taken-over1"}`)
+	c.Request.Header.Set("If-Match", userETag(admin))
+	c.Set(authorization.RoleKey, domain.RoleOperator)
+
+	UpdateUser(c)
+
+	assert.EqualValues(t, http.StatusForbidden, response.Code)
+
+	c, response = newUserContext(http.MethodDelete, id, "")
+	c.Request.Header.Set("If-Match", userETag(admin))
+	c.Set(authorization.RoleKey, domain.RoleOperator)
+
+	DeleteUser(c)
+
+	assert.EqualValues(t, http.StatusForbidden, response.Code)
+
+	stored, err := domain.UserDao.GetUser(int64(admin.Id))
+	assert.Nil(t, err)
+	assert.EqualValues(t, admin.Version, stored.Version)
+}
+
+func TestOperatorCannotChangeCredentialsOfOthers(t *testing.T) {
+
+	viewer := createTestUser(t, "guarded-viewer@domain.com", domain.RoleViewer)
+	id := strconv.FormatUint(viewer.Id, 10)
+
+	for _, body := range []string{`{"password":"Taken-over1"}`, `{"email":"attacker@domain.com"}`} {
+
+		c, response := newUserContext(http.MethodPatch, id, body)
+		c.Request.Header.Set("If-Match", userETag(viewer))
+		c.Set(authorization.RoleKey, domain.RoleOperator)
+		c.Set(authorization.UserIdKey, int64(1))
+
+		UpdateUser(c)
+
+		assert.EqualValues(t, http.StatusForbidden, response.Code)
+	}
+
+	// the profile can still be edited, and users can change their own credentials
+	c, response := newUserContext(http.MethodPatch, id, `{"first_name":"Edited"}`)
+	c.Request.Header.Set("If-Match", userETag(viewer))
+	c.Set(authorization.RoleKey, domain.RoleOperator)
+
+	UpdateUser(c)
+
+	assert.EqualValues(t, http.StatusOK, response.Code)
+
+	edited, _ := domain.UserDao.GetUser(int64(viewer.Id))
+	c, response = newUserContext(http.MethodPatch, id, `{"password":"Own-password1"}`)
+	c.Request.Header.Set("If-Match", userETag(edited))
+	c.Set(authorization.RoleKey, domain.RoleOperator)
+	c.Set(authorization.UserIdKey, int64(viewer.Id))
+
+	UpdateUser(c)
+
+	assert.EqualValues(t, http.StatusOK, response.Code)
+}
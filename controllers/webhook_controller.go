@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"strconv"
+)
+
+func CreateWebhook(c *gin.Context) {
+
+	var request domain.WebhookRequest
+	if bindError := c.ShouldBindJSON(&request); bindError != nil {
+
+		responseError := util.ResponseError{
+			Code:    http.StatusBadRequest,
+			Message: "invalid json body",
+		}
+
+		c.JSON(responseError.Code, responseError)
+		return
+	}
+
+	created, err := services.CreateWebhook(request, actor(c))
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func ListWebhooks(c *gin.Context) {
+
+	webhooks, err := services.ListWebhooks()
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+func DeleteWebhook(c *gin.Context) {
+
+	webhookId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	if err := services.DeleteWebhook(webhookId); err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func GetWebhookDeliveries(c *gin.Context) {
+
+	webhookId, idError := getIdParam(c)
+	if idError != nil {
+
+		c.JSON(idError.Code, idError)
+		return
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+
+		parsedLimit, parserError := strconv.Atoi(value)
+		if parserError != nil {
+
+			responseError := util.ResponseError{
+				Code:    http.StatusBadRequest,
+				Message: "invalid limit",
+			}
+
+			c.JSON(responseError.Code, responseError)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	deliveries, err := services.GetWebhookDeliveries(webhookId, limit)
+
+	if err != nil {
+
+		c.JSON(err.Code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ApiKeyDao apiKeyDaoInterface
+)
+
+func init() {
+
+	ApiKeyDao = newApiKeyDaoImpl()
+}
+
+// ApiKey describes a key handed to another service. Only the hash of the key is stored, the key
+// itself is returned once, when it is created.
+type ApiKey struct {
+	Id        uint64     `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Hash      string     `json:"-"`
+}
+
+type ApiKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreatedApiKey is the only response carrying the plain key.
+type CreatedApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+func (k *ApiKey) IsActive(at time.Time) bool {
+
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || at.Before(*k.ExpiresAt)
+}
+
+func (k *ApiKey) HasScope(scope string) bool {
+
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ApiKeyRequest) Validate() *util.ResponseError {
+
+	r.Name = strings.TrimSpace(r.Name)
+
+	var fields []util.FieldError
+	if r.Name == "" {
+		fields = append(fields, util.FieldError{Field: "name", Message: "is required"})
+	}
+	if len(r.Scopes) == 0 {
+		fields = append(fields, util.FieldError{Field: "scopes", Message: "at least one scope is required"})
+	}
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(now()) {
+		fields = append(fields, util.FieldError{Field: "expires_at", Message: "must be in the future"})
+	}
+
+	if len(fields) > 0 {
+		return &util.ResponseError{
+			Message: "invalid api key",
+			Code:    http.StatusBadRequest,
+			Fields:  fields,
+		}
+	}
+
+	return nil
+}
+
+type apiKeyDaoInterface interface {
+	CreateApiKey(key *ApiKey) (*ApiKey, *util.ResponseError)
+	ListApiKeys() ([]ApiKey, *util.ResponseError)
+	GetApiKeyByHash(hash string) (*ApiKey, *util.ResponseError)
+	RevokeApiKey(keyId int64) (*ApiKey, *util.ResponseError)
+}
+
+type apiKeyDaoImpl struct {
+	mu     sync.RWMutex
+	keys   map[int64]ApiKey
+	hashes map[string]int64
+	lastId uint64
+	// file is where the file store keeps the keys, empty in memory
+	file string
+}
+
+func newApiKeyDaoImpl() *apiKeyDaoImpl {
+
+	return &apiKeyDaoImpl{
+		keys:   make(map[int64]ApiKey),
+		hashes: make(map[string]int64),
+	}
+}
+
+func (a *apiKeyDaoImpl) CreateApiKey(key *ApiKey) (*ApiKey, *util.ResponseError) {
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.lastId++
+	created := *key
+	created.Id = a.lastId
+	created.CreatedAt = now()
+	created.RevokedAt = nil
+
+	a.keys[int64(created.Id)] = created
+	a.hashes[created.Hash] = int64(created.Id)
+
+	if err := a.persist(); err != nil {
+		delete(a.keys, int64(created.Id))
+		delete(a.hashes, created.Hash)
+		a.lastId--
+		return nil, persistError("api keys")
+	}
+
+	return &created, nil
+}
+
+func (a *apiKeyDaoImpl) ListApiKeys() ([]ApiKey, *util.ResponseError) {
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	keys := make([]ApiKey, 0, len(a.keys))
+	for _, key := range a.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Id < keys[j].Id })
+
+	return keys, nil
+}
+
+func (a *apiKeyDaoImpl) GetApiKeyByHash(hash string) (*ApiKey, *util.ResponseError) {
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	id, present := a.hashes[hash]
+	if !present {
+		return nil, apiKeyNotFoundError()
+	}
+
+	key := a.keys[id]
+	return &key, nil
+}
+
+func (a *apiKeyDaoImpl) RevokeApiKey(keyId int64) (*ApiKey, *util.ResponseError) {
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key, present := a.keys[keyId]
+	if !present {
+		return nil, apiKeyNotFoundError()
+	}
+
+	if key.RevokedAt == nil {
+		previous := key
+		revokedAt := now()
+		key.RevokedAt = &revokedAt
+		a.keys[keyId] = key
+
+		if err := a.persist(); err != nil {
+			a.keys[keyId] = previous
+			return nil, persistError("api keys")
+		}
+	}
+
+	return &key, nil
+}
+
+func apiKeyNotFoundError() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "api key not found",
+		Code:    http.StatusNotFound,
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/leandrotula/golangmicroservice/util"
+	"strings"
+)
+
+const apiKeyColumns = "id, name, prefix, scopes, created_by, created_at, expires_at, revoked_at, key_hash"
+
+// apiKeySqlDao shares the database of the user store. Lookups always hit the database, so
+// several processes using the same file see keys created or revoked by each other.
+type apiKeySqlDao struct {
+	db *sql.DB
+}
+
+func newApiKeySqlDao(db *sql.DB) *apiKeySqlDao {
+
+	return &apiKeySqlDao{db: db}
+}
+
+func (s *apiKeySqlDao) CreateApiKey(key *ApiKey) (*ApiKey, *util.ResponseError) {
+
+	created := *key
+	created.CreatedAt = now()
+	created.RevokedAt = nil
+
+	result, err := s.db.Exec(`INSERT INTO api_keys (name, prefix, scopes, created_by, created_at, expires_at, key_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		created.Name, created.Prefix, strings.Join(created.Scopes, ","), created.CreatedBy, created.CreatedAt,
+		created.ExpiresAt, created.Hash)
+	if err != nil {
+		return nil, databaseError()
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, databaseError()
+	}
+	created.Id = uint64(id)
+
+	return &created, nil
+}
+
+func (s *apiKeySqlDao) ListApiKeys() ([]ApiKey, *util.ResponseError) {
+
+	rows, err := s.db.Query(`SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY id`)
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer rows.Close()
+
+	keys := make([]ApiKey, 0)
+	for rows.Next() {
+		var key ApiKey
+		if err := scanApiKey(rows, &key); err != nil {
+			return nil, databaseError()
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, databaseError()
+	}
+
+	return keys, nil
+}
+
+func (s *apiKeySqlDao) GetApiKeyByHash(hash string) (*ApiKey, *util.ResponseError) {
+
+	return findApiKey(s.db, `key_hash = ?`, hash)
+}
+
+func (s *apiKeySqlDao) RevokeApiKey(keyId int64) (*ApiKey, *util.ResponseError) {
+
+	if _, err := s.db.Exec(`UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`, now(), keyId); err != nil {
+		return nil, databaseError()
+	}
+
+	return findApiKey(s.db, `id = ?`, keyId)
+}
+
+func findApiKey(db queryRower, condition string, value interface{}) (*ApiKey, *util.ResponseError) {
+
+	var key ApiKey
+	if err := scanApiKey(db.QueryRow(`SELECT `+apiKeyColumns+` FROM api_keys WHERE `+condition, value), &key); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, apiKeyNotFoundError()
+		}
+
+		return nil, databaseError()
+	}
+
+	return &key, nil
+}
+
+func scanApiKey(row interface{ Scan(dest ...interface{}) error }, key *ApiKey) error {
+
+	var scopes string
+	var expiresAt, revokedAt sql.NullTime
+	if err := row.Scan(&key.Id, &key.Name, &key.Prefix, &scopes, &key.CreatedBy, &key.CreatedAt, &expiresAt, &revokedAt, &key.Hash); err != nil {
+		return err
+	}
+
+	key.Scopes = strings.Split(scopes, ",")
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return nil
+}
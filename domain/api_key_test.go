@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func assertApiKeys(t *testing.T, dao apiKeyDaoInterface) {
+
+	expiresAt := now().Add(time.Hour)
+	created, err := dao.CreateApiKey(&ApiKey{Name: "ci", Prefix: "gmk_1234", Scopes: []string{"users:read", "repositories:create"},
+		CreatedBy: "admin", ExpiresAt: &expiresAt, Hash: "hash"})
+	assert.Nil(t, err)
+	assert.NotZero(t, created.Id)
+
+	found, err := dao.GetApiKeyByHash("hash")
+	assert.Nil(t, err)
+	assert.Equal(t, created.Id, found.Id)
+	assert.Equal(t, []string{"users:read", "repositories:create"}, found.Scopes)
+	assert.True(t, found.IsActive(now()))
+	assert.False(t, found.IsActive(expiresAt.Add(time.Second)))
+
+	_, err = dao.GetApiKeyByHash("other")
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	revoked, err := dao.RevokeApiKey(int64(created.Id))
+	assert.Nil(t, err)
+	assert.False(t, revoked.IsActive(now()))
+
+	keys, err := dao.ListApiKeys()
+	assert.Nil(t, err)
+	assert.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].RevokedAt)
+}
+
+func TestApiKeysMemory(t *testing.T) {
+
+	assertApiKeys(t, newApiKeyDaoImpl())
+}
+
+func TestApiKeysSql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertApiKeys(t, newApiKeySqlDao(dao.db))
+}
+
+func TestApiKeysFile(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "api-keys")
+	defer os.RemoveAll(dir)
+
+	dao, err := newApiKeyFileDao(dir)
+	assert.Nil(t, err)
+	assertApiKeys(t, dao)
+
+	reopened, err := newApiKeyFileDao(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, dao.keys, reopened.keys)
+	assert.Equal(t, dao.hashes, reopened.hashes)
+	assert.Equal(t, dao.lastId, reopened.lastId)
+
+	// nothing is kept in memory when it cannot be written
+	os.RemoveAll(dir)
+	_, createError := reopened.CreateApiKey(&ApiKey{Name: "lost", Hash: "lost-hash"})
+	assert.EqualValues(t, http.StatusInternalServerError, createError.Code)
+	assert.Equal(t, dao.keys, reopened.keys)
+	assert.Equal(t, dao.lastId, reopened.lastId)
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type migration struct {
+	version int
+	// check runs before the statements, in the same transaction, to explain data they would reject
+	check      func(tx *sql.Tx) error
+	statements []string
+}
+
+// migrations must only ever be appended to, a released version is never edited.
+var migrations = []migration{
+	{
+		version: 1,
+		statements: []string{
+			`CREATE TABLE users (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				first_name TEXT NOT NULL DEFAULT '',
+				last_name TEXT NOT NULL DEFAULT '',
+				email TEXT NOT NULL DEFAULT ''
+			)`,
+		},
+	},
+	{
+		version: 2,
+		check:   checkUniqueEmails,
+		statements: []string{
+			`UPDATE users SET email = LOWER(TRIM(email))`,
+			`CREATE UNIQUE INDEX users_email_unique ON users (email)`,
+		},
+	},
+	{
+		version: 3,
+		statements: []string{
+			`ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+		},
+	},
+	{
+		version: 4,
+		statements: []string{
+			`ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP NULL`,
+			`CREATE TABLE user_history (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				user_id INTEGER NOT NULL,
+				action TEXT NOT NULL,
+				actor TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				changes TEXT NOT NULL DEFAULT ''
+			)`,
+			`CREATE INDEX user_history_user ON user_history (user_id, id)`,
+		},
+	},
+	{
+		version: 5,
+		statements: []string{
+			`ALTER TABLE users ADD COLUMN password_hash TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version: 6,
+		statements: []string{
+			`ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'viewer'`,
+		},
+	},
+	{
+		version: 7,
+		statements: []string{
+			`CREATE TABLE api_keys (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL,
+				prefix TEXT NOT NULL,
+				scopes TEXT NOT NULL,
+				created_by TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				expires_at TIMESTAMP NULL,
+				revoked_at TIMESTAMP NULL,
+				key_hash TEXT NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX api_keys_hash_unique ON api_keys (key_hash)`,
+		},
+	},
+	{
+		version: 8,
+		statements: []string{
+			`CREATE TABLE repository_owners (
+				repository_id INTEGER PRIMARY KEY,
+				name TEXT NOT NULL,
+				full_name TEXT NOT NULL,
+				user_id INTEGER NOT NULL DEFAULT 0,
+				api_key_id INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX repository_owners_user ON repository_owners (user_id)`,
+		},
+	},
+	{
+		version: 9,
+		statements: []string{
+			`CREATE TABLE webhooks (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				url TEXT NOT NULL,
+				events TEXT NOT NULL DEFAULT '',
+				secret TEXT NOT NULL,
+				last_event_id INTEGER NOT NULL DEFAULT 0,
+				created_by TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE TABLE webhook_deliveries (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				webhook_id INTEGER NOT NULL,
+				event_id INTEGER NOT NULL,
+				event_type TEXT NOT NULL,
+				attempt INTEGER NOT NULL,
+				status_code INTEGER NOT NULL DEFAULT 0,
+				error TEXT NOT NULL DEFAULT '',
+				success BOOLEAN NOT NULL,
+				delivered_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX webhook_deliveries_webhook ON webhook_deliveries (webhook_id, id)`,
+		},
+	},
+	{
+		version: 10,
+		statements: []string{
+			`CREATE INDEX repository_owners_full_name ON repository_owners (full_name COLLATE NOCASE)`,
+		},
+	},
+}
+
+// migrate brings the schema up to the latest version, running every pending migration in its own
+// transaction and recording it in schema_migrations.
+func migrate(db *sql.DB) error {
+
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+
+		if m.version <= current {
+			continue
+		}
+
+		if err := runMigration(db, m); err != nil {
+			return fmt.Errorf("migration %d failed: %v", m.version, err)
+		}
+	}
+
+	return nil
+}
+
+func runMigration(db *sql.DB, m migration) error {
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if m.check != nil {
+		if err := m.check(tx); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, statement := range m.statements {
+		if _, err := tx.Exec(statement); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, m.version); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkUniqueEmails lists the users sharing an email (empty ones included) once normalized, which
+// would make users_email_unique fail with a bare constraint error. They have to be fixed by hand.
+func checkUniqueEmails(tx *sql.Tx) error {
+
+	rows, err := tx.Query(`SELECT LOWER(TRIM(email)), GROUP_CONCAT(id, ', ') FROM users
+		GROUP BY LOWER(TRIM(email)) HAVING COUNT(*) > 1 ORDER BY MIN(id)`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var conflicts []string
+	for rows.Next() {
+		var email, ids string
+		if err := rows.Scan(&email, &ids); err != nil {
+			return err
+		}
+		if email == "" {
+			email = "(empty)"
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s (users %s)", email, ids))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("emails must be unique, fix the users sharing one before migrating: %s",
+			strings.Join(conflicts, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	RepositoryOwnerDao repositoryOwnerDaoInterface
+)
+
+func init() {
+
+	RepositoryOwnerDao = newRepositoryOwnerDaoImpl()
+}
+
+// RepositoryOwner links a GitHub repository created through the api to whoever asked for it:
+// a user, or an api key for service callers.
+type RepositoryOwner struct {
+	RepositoryId int64     `json:"repository_id"`
+	Name         string    `json:"name"`
+	FullName     string    `json:"full_name"`
+	UserId       uint64    `json:"user_id,omitempty"`
+	ApiKeyId     uint64    `json:"api_key_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type repositoryOwnerDaoInterface interface {
+	SaveRepositoryOwner(owner *RepositoryOwner) (*RepositoryOwner, *util.ResponseError)
+	GetRepositoryOwner(repositoryId int64) (*RepositoryOwner, *util.ResponseError)
+	ListUserRepositories(userId int64) ([]RepositoryOwner, *util.ResponseError)
+	// GetRepositoryOwnerByFullName ignores case like GitHub does, the newest repository wins when
+	// a name was reused.
+	GetRepositoryOwnerByFullName(fullName string) (*RepositoryOwner, *util.ResponseError)
+	DeleteRepositoryOwner(repositoryId int64) *util.ResponseError
+}
+
+type repositoryOwnerDaoImpl struct {
+	mu     sync.RWMutex
+	owners map[int64]RepositoryOwner
+	// file is where the file store keeps the owners, empty in memory
+	file string
+}
+
+func newRepositoryOwnerDaoImpl() *repositoryOwnerDaoImpl {
+
+	return &repositoryOwnerDaoImpl{owners: make(map[int64]RepositoryOwner)}
+}
+
+func (r *repositoryOwnerDaoImpl) SaveRepositoryOwner(owner *RepositoryOwner) (*RepositoryOwner, *util.ResponseError) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *owner
+	saved.CreatedAt = now()
+	previous, existed := r.owners[saved.RepositoryId]
+	r.owners[saved.RepositoryId] = saved
+
+	if err := r.persist(); err != nil {
+		if existed {
+			r.owners[saved.RepositoryId] = previous
+		} else {
+			delete(r.owners, saved.RepositoryId)
+		}
+		return nil, persistError("repository owners")
+	}
+
+	return &saved, nil
+}
+
+func (r *repositoryOwnerDaoImpl) GetRepositoryOwner(repositoryId int64) (*RepositoryOwner, *util.ResponseError) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	owner, present := r.owners[repositoryId]
+	if !present {
+		return nil, repositoryNotFoundError()
+	}
+
+	return &owner, nil
+}
+
+func (r *repositoryOwnerDaoImpl) ListUserRepositories(userId int64) ([]RepositoryOwner, *util.ResponseError) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	repositories := make([]RepositoryOwner, 0)
+	for _, owner := range r.owners {
+		if int64(owner.UserId) == userId {
+			repositories = append(repositories, owner)
+		}
+	}
+	sort.Slice(repositories, func(i, j int) bool { return repositories[i].RepositoryId < repositories[j].RepositoryId })
+
+	return repositories, nil
+}
+
+func (r *repositoryOwnerDaoImpl) GetRepositoryOwnerByFullName(fullName string) (*RepositoryOwner, *util.ResponseError) {
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *RepositoryOwner
+	for _, owner := range r.owners {
+		if strings.EqualFold(owner.FullName, fullName) && (found == nil || owner.RepositoryId > found.RepositoryId) {
+			candidate := owner
+			found = &candidate
+		}
+	}
+
+	if found == nil {
+		return nil, repositoryNotFoundError()
+	}
+
+	return found, nil
+}
+
+func (r *repositoryOwnerDaoImpl) DeleteRepositoryOwner(repositoryId int64) *util.ResponseError {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owner, present := r.owners[repositoryId]
+	if !present {
+		return repositoryNotFoundError()
+	}
+	delete(r.owners, repositoryId)
+
+	if err := r.persist(); err != nil {
+		r.owners[repositoryId] = owner
+		return persistError("repository owners")
+	}
+
+	return nil
+}
+
+func repositoryNotFoundError() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "repository not found",
+		Code:    http.StatusNotFound,
+	}
+}
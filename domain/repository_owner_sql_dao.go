@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/leandrotula/golangmicroservice/util"
+)
+
+const repositoryOwnerColumns = "repository_id, name, full_name, user_id, api_key_id, created_at"
+
+type repositoryOwnerSqlDao struct {
+	db *sql.DB
+}
+
+func newRepositoryOwnerSqlDao(db *sql.DB) *repositoryOwnerSqlDao {
+
+	return &repositoryOwnerSqlDao{db: db}
+}
+
+func (s *repositoryOwnerSqlDao) SaveRepositoryOwner(owner *RepositoryOwner) (*RepositoryOwner, *util.ResponseError) {
+
+	saved := *owner
+	saved.CreatedAt = now()
+
+	// GitHub never reuses repository ids, a second record can only be a retry of the same one
+	_, err := s.db.Exec(`INSERT OR REPLACE INTO repository_owners (`+repositoryOwnerColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		saved.RepositoryId, saved.Name, saved.FullName, saved.UserId, saved.ApiKeyId, saved.CreatedAt)
+	if err != nil {
+		return nil, databaseError()
+	}
+
+	return &saved, nil
+}
+
+func (s *repositoryOwnerSqlDao) GetRepositoryOwner(repositoryId int64) (*RepositoryOwner, *util.ResponseError) {
+
+	var owner RepositoryOwner
+	row := s.db.QueryRow(`SELECT `+repositoryOwnerColumns+` FROM repository_owners WHERE repository_id = ?`, repositoryId)
+
+	if err := scanRepositoryOwner(row, &owner); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, repositoryNotFoundError()
+		}
+
+		return nil, databaseError()
+	}
+
+	return &owner, nil
+}
+
+func (s *repositoryOwnerSqlDao) ListUserRepositories(userId int64) ([]RepositoryOwner, *util.ResponseError) {
+
+	rows, err := s.db.Query(`SELECT `+repositoryOwnerColumns+` FROM repository_owners WHERE user_id = ?
+		ORDER BY repository_id`, userId)
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer rows.Close()
+
+	repositories := make([]RepositoryOwner, 0)
+	for rows.Next() {
+		var owner RepositoryOwner
+		if err := scanRepositoryOwner(rows, &owner); err != nil {
+			return nil, databaseError()
+		}
+		repositories = append(repositories, owner)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, databaseError()
+	}
+
+	return repositories, nil
+}
+
+func (s *repositoryOwnerSqlDao) GetRepositoryOwnerByFullName(fullName string) (*RepositoryOwner, *util.ResponseError) {
+
+	var owner RepositoryOwner
+	row := s.db.QueryRow(`SELECT `+repositoryOwnerColumns+` FROM repository_owners WHERE full_name = ? COLLATE NOCASE
+		ORDER BY repository_id DESC LIMIT 1`, fullName)
+
+	if err := scanRepositoryOwner(row, &owner); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, repositoryNotFoundError()
+		}
+
+		return nil, databaseError()
+	}
+
+	return &owner, nil
+}
+
+func (s *repositoryOwnerSqlDao) DeleteRepositoryOwner(repositoryId int64) *util.ResponseError {
+
+	result, err := s.db.Exec(`DELETE FROM repository_owners WHERE repository_id = ?`, repositoryId)
+	if err != nil {
+		return databaseError()
+	}
+
+	if deleted, err := result.RowsAffected(); err != nil || deleted == 0 {
+		return repositoryNotFoundError()
+	}
+
+	return nil
+}
+
+func scanRepositoryOwner(row interface{ Scan(dest ...interface{}) error }, owner *RepositoryOwner) error {
+
+	return row.Scan(&owner.RepositoryId, &owner.Name, &owner.FullName, &owner.UserId, &owner.ApiKeyId, &owner.CreatedAt)
+}
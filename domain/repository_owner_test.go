@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func assertRepositoryOwners(t *testing.T, dao repositoryOwnerDaoInterface) {
+
+	for _, owner := range []RepositoryOwner{
+		{RepositoryId: 20, Name: "second", FullName: "octocat/second", UserId: 1},
+		{RepositoryId: 10, Name: "first", FullName: "octocat/first", UserId: 1},
+		{RepositoryId: 30, Name: "ci", FullName: "octocat/ci", ApiKeyId: 4},
+	} {
+		_, err := dao.SaveRepositoryOwner(&owner)
+		assert.Nil(t, err)
+	}
+
+	owner, err := dao.GetRepositoryOwner(30)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 4, owner.ApiKeyId)
+	assert.EqualValues(t, 0, owner.UserId)
+
+	_, err = dao.GetRepositoryOwner(40)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	repositories, err := dao.ListUserRepositories(1)
+	assert.Nil(t, err)
+	assert.Len(t, repositories, 2)
+	assert.Equal(t, "first", repositories[0].Name)
+
+	repositories, err = dao.ListUserRepositories(2)
+	assert.Nil(t, err)
+	assert.Len(t, repositories, 0)
+
+	// a repository deleted outside the api and created again under the same name
+	_, err = dao.SaveRepositoryOwner(&RepositoryOwner{RepositoryId: 50, Name: "first", FullName: "octocat/first", UserId: 2})
+	assert.Nil(t, err)
+
+	owner, err = dao.GetRepositoryOwnerByFullName("OctoCat/First")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 50, owner.RepositoryId)
+
+	_, err = dao.GetRepositoryOwnerByFullName("octocat/missing")
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	assert.Nil(t, dao.DeleteRepositoryOwner(50))
+	assert.Equal(t, http.StatusNotFound, dao.DeleteRepositoryOwner(50).Code)
+
+	owner, err = dao.GetRepositoryOwnerByFullName("octocat/first")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 10, owner.RepositoryId)
+}
+
+func TestRepositoryOwnersMemory(t *testing.T) {
+
+	assertRepositoryOwners(t, newRepositoryOwnerDaoImpl())
+}
+
+func TestRepositoryOwnersSql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertRepositoryOwners(t, newRepositoryOwnerSqlDao(dao.db))
+}
+
+func TestRepositoryOwnersFile(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "repository-owners")
+	defer os.RemoveAll(dir)
+
+	dao, err := newRepositoryOwnerFileDao(dir)
+	assert.Nil(t, err)
+	assertRepositoryOwners(t, dao)
+
+	reopened, err := newRepositoryOwnerFileDao(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, len(dao.owners), len(reopened.owners))
+	for id, owner := range dao.owners {
+		assert.True(t, owner.CreatedAt.Equal(reopened.owners[id].CreatedAt))
+		assert.Equal(t, owner.FullName, reopened.owners[id].FullName)
+	}
+
+	os.RemoveAll(dir)
+	_, saveError := reopened.SaveRepositoryOwner(&RepositoryOwner{RepositoryId: 4242, FullName: "octocat/lost"})
+	assert.EqualValues(t, http.StatusInternalServerError, saveError.Code)
+	_, saveError = reopened.GetRepositoryOwner(4242)
+	assert.EqualValues(t, http.StatusNotFound, saveError.Code)
+}
@@ -0,0 +1,19 @@
+package domain
+
+const (
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleViewer   = "viewer"
+
+	DefaultRole = RoleViewer
+)
+
+func IsValidRole(role string) bool {
+
+	switch role {
+	case RoleAdmin, RoleOperator, RoleViewer:
+		return true
+	}
+
+	return false
+}
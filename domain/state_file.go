@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/leandrotula/golangmicroservice/util"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+const (
+	apiKeysFileName          = "api_keys.json"
+	repositoryOwnersFileName = "repository_owners.json"
+	webhooksFileName         = "webhooks.json"
+)
+
+// The file store keeps the users in a log, the api keys, repository owners and webhooks are
+// small enough to be rewritten whole after every change.
+
+type apiKeyState struct {
+	LastId uint64         `json:"last_id"`
+	Keys   []storedApiKey `json:"keys"`
+}
+
+// storedApiKey carries the hash ApiKey leaves out of its json.
+type storedApiKey struct {
+	ApiKey
+	Hash string `json:"hash"`
+}
+
+type webhookState struct {
+	LastId         uint64            `json:"last_id"`
+	LastDeliveryId uint64            `json:"last_delivery_id"`
+	Webhooks       []storedWebhook   `json:"webhooks"`
+	Deliveries     []WebhookDelivery `json:"deliveries"`
+}
+
+// storedWebhook carries the secret Webhook leaves out of its json.
+type storedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+func newApiKeyFileDao(dir string) (*apiKeyDaoImpl, error) {
+
+	dao := newApiKeyDaoImpl()
+	dao.file = filepath.Join(dir, apiKeysFileName)
+
+	var state apiKeyState
+	if err := readStateFile(dao.file, &state); err != nil {
+		return nil, err
+	}
+
+	dao.lastId = state.LastId
+	for _, stored := range state.Keys {
+		key := stored.ApiKey
+		key.Hash = stored.Hash
+		dao.keys[int64(key.Id)] = key
+		dao.hashes[key.Hash] = int64(key.Id)
+	}
+
+	return dao, nil
+}
+
+func (a *apiKeyDaoImpl) persist() error {
+
+	if a.file == "" {
+		return nil
+	}
+
+	state := apiKeyState{LastId: a.lastId, Keys: make([]storedApiKey, 0, len(a.keys))}
+	for _, key := range a.keys {
+		state.Keys = append(state.Keys, storedApiKey{ApiKey: key, Hash: key.Hash})
+	}
+
+	return writeStateFile(a.file, state)
+}
+
+func newRepositoryOwnerFileDao(dir string) (*repositoryOwnerDaoImpl, error) {
+
+	dao := newRepositoryOwnerDaoImpl()
+	dao.file = filepath.Join(dir, repositoryOwnersFileName)
+
+	var owners []RepositoryOwner
+	if err := readStateFile(dao.file, &owners); err != nil {
+		return nil, err
+	}
+
+	for _, owner := range owners {
+		dao.owners[owner.RepositoryId] = owner
+	}
+
+	return dao, nil
+}
+
+func (r *repositoryOwnerDaoImpl) persist() error {
+
+	if r.file == "" {
+		return nil
+	}
+
+	owners := make([]RepositoryOwner, 0, len(r.owners))
+	for _, owner := range r.owners {
+		owners = append(owners, owner)
+	}
+
+	return writeStateFile(r.file, owners)
+}
+
+func newWebhookFileDao(dir string) (*webhookDaoImpl, error) {
+
+	dao := newWebhookDaoImpl()
+	dao.file = filepath.Join(dir, webhooksFileName)
+
+	var state webhookState
+	if err := readStateFile(dao.file, &state); err != nil {
+		return nil, err
+	}
+
+	dao.lastId = state.LastId
+	dao.lastDeliveryId = state.LastDeliveryId
+	for _, stored := range state.Webhooks {
+		webhook := stored.Webhook
+		webhook.Secret = stored.Secret
+		dao.webhooks[int64(webhook.Id)] = webhook
+	}
+	// the deliveries were written oldest first
+	for _, delivery := range state.Deliveries {
+		dao.deliveries[int64(delivery.WebhookId)] = append(dao.deliveries[int64(delivery.WebhookId)], delivery)
+	}
+
+	return dao, nil
+}
+
+func (w *webhookDaoImpl) persist() error {
+
+	if w.file == "" {
+		return nil
+	}
+
+	state := webhookState{LastId: w.lastId, LastDeliveryId: w.lastDeliveryId,
+		Webhooks: make([]storedWebhook, 0, len(w.webhooks))}
+	for _, webhook := range w.webhooks {
+		state.Webhooks = append(state.Webhooks, storedWebhook{Webhook: webhook, Secret: webhook.Secret})
+	}
+	for _, deliveries := range w.deliveries {
+		state.Deliveries = append(state.Deliveries, deliveries...)
+	}
+
+	return writeStateFile(w.file, state)
+}
+
+// readStateFile leaves state untouched when the file was never written.
+func readStateFile(name string, state interface{}) error {
+
+	data, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, state)
+}
+
+// writeStateFile replaces the file in one step, a crash leaves either the old or the new state.
+func writeStateFile(name string, state interface{}) error {
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmpName := name + ".tmp"
+	tmpFile, err := os.OpenFile(tmpName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, name)
+}
+
+func persistError(what string) *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "could not persist " + what,
+		Code:    http.StatusInternalServerError,
+	}
+}
@@ -1,8 +1,152 @@
 package domain
 
+import (
+	"github.com/leandrotula/golangmicroservice/util"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
 type User struct {
 	Id uint64 `json:"id"`
 	FirstName string `json:"first_name"`
 	LastName string `json:"last_name"`
 	Email string `json:"email"`
+	Version uint64 `json:"version"`
+	Role string `json:"role"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Password is only read from requests, HashPassword turns it into PasswordHash, which is
+	// the only one stored and is never written back to clients.
+	Password string `json:"password,omitempty"`
+	PasswordHash string `json:"-"`
+}
+
+const minPasswordLength = 8
+
+func (u *User) IsDeleted() bool {
+
+	return u.DeletedAt != nil
+}
+
+func (u *User) Validate() *util.ResponseError {
+
+	u.FirstName = strings.TrimSpace(u.FirstName)
+	u.LastName = strings.TrimSpace(u.LastName)
+	u.Email = NormalizeEmail(u.Email)
+
+	if !isValidEmail(u.Email) {
+		return &util.ResponseError{
+			Message: "invalid email address",
+			Code:    http.StatusBadRequest,
+			Fields: []util.FieldError{
+				{Field: "email", Message: "must be a valid address like name@domain.com"},
+			},
+		}
+	}
+
+	if u.Role != "" && !IsValidRole(u.Role) {
+		return &util.ResponseError{
+			Message: "invalid role",
+			Code:    http.StatusBadRequest,
+			Fields: []util.FieldError{
+				{Field: "role", Message: "must be one of admin, operator or viewer"},
+			},
+		}
+	}
+
+	if u.Password != "" && len(u.Password) < minPasswordLength {
+		return &util.ResponseError{
+			Message: "invalid password",
+			Code:    http.StatusBadRequest,
+			Fields: []util.FieldError{
+				{Field: "password", Message: "must have at least 8 characters"},
+			},
+		}
+	}
+
+	return nil
+}
+
+// HashPassword replaces the plain Password, if any, by its bcrypt hash.
+func (u *User) HashPassword() *util.ResponseError {
+
+	if u.Password == "" {
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return &util.ResponseError{
+			Message: "could not hash password",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	u.PasswordHash = string(hash)
+	u.Password = ""
+	return nil
+}
+
+func (u *User) CheckPassword(password string) bool {
+
+	if u.PasswordHash == "" {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// NormalizeEmail gives the form emails are stored and compared in, so uniqueness ignores case.
+func NormalizeEmail(email string) string {
+
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// isValidEmail accepts a bare RFC 5322 addr-spec (no display name or angle brackets) whose
+// domain has at least two labels.
+func isValidEmail(email string) bool {
+
+	if email == "" {
+		return false
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || address.Name != "" {
+		return false
+	}
+
+	at := strings.LastIndex(email, "@")
+	domain := email[at+1:]
+
+	return strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+func emailTakenError(email string) *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "email already in use",
+		Code:    http.StatusConflict,
+		Fields: []util.FieldError{
+			{Field: "email", Message: "address " + email + " is already registered to another user"},
+		},
+	}
+}
+
+// VersionMismatchError is the answer to a write based on a version that is no longer current.
+func VersionMismatchError() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "user was modified by another request",
+		Code:    http.StatusPreconditionFailed,
+	}
+}
+
+func notDeletedError() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "user is not deleted",
+		Code:    http.StatusConflict,
+	}
 }
@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"container/list"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CacheStats is a snapshot of the counters of the user cache.
+type CacheStats struct {
+	Size         int    `json:"size"`
+	Capacity     int    `json:"capacity"`
+	Hits         uint64 `json:"hits"`
+	NegativeHits uint64 `json:"negative_hits"`
+	Misses       uint64 `json:"misses"`
+	Evictions    uint64 `json:"evictions"`
+	Expirations  uint64 `json:"expirations"`
+}
+
+type cacheEntry struct {
+	userId    int64
+	user      *User
+	expiresAt time.Time
+}
+
+// cachedUserDao is a read-through cache in front of another dao for single user lookups. Users
+// are cached deleted or not, GetUser hides the deleted ones, and unknown ids are remembered for a
+// shorter time. Every write through the cache drops the entry of the user, writes made by another
+// process are only seen once the entry expires.
+type cachedUserDao struct {
+	userDaoInterface
+	mu          sync.Mutex
+	capacity    int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	entries     map[int64]*list.Element
+	order       *list.List
+	stats       CacheStats
+	// generation changes on every invalidation, a lookup racing with a write must not store
+	// what it read before the write
+	generation uint64
+}
+
+func newCachedUserDao(dao userDaoInterface, capacity int, ttl time.Duration, negativeTTL time.Duration) *cachedUserDao {
+
+	return &cachedUserDao{
+		userDaoInterface: dao,
+		capacity:         capacity,
+		ttl:              ttl,
+		negativeTTL:      negativeTTL,
+		entries:          make(map[int64]*list.Element),
+		order:            list.New(),
+	}
+}
+
+// ConfigureUserCache puts a cache in front of the current UserDao, a capacity of zero leaves it as is.
+func ConfigureUserCache(capacity int, ttl time.Duration, negativeTTL time.Duration) {
+
+	if capacity <= 0 {
+		return
+	}
+
+	UserDao = newCachedUserDao(UserDao, capacity, ttl, negativeTTL)
+}
+
+// UserCacheStats returns the counters of the user cache, when there is one.
+func UserCacheStats() (*CacheStats, *util.ResponseError) {
+
+	cache, ok := UserDao.(*cachedUserDao)
+	if !ok {
+		return nil, &util.ResponseError{
+			Message: "user cache is disabled",
+			Code:    http.StatusNotFound,
+		}
+	}
+
+	return cache.Stats(), nil
+}
+
+func (c *cachedUserDao) GetUser(userId int64) (*User, *util.ResponseError) {
+
+	user, err := c.GetUserIncludingDeleted(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, notFoundError()
+	}
+
+	return user, nil
+}
+
+func (c *cachedUserDao) GetUserIncludingDeleted(userId int64) (*User, *util.ResponseError) {
+
+	user, found, generation := c.lookup(userId)
+	if found {
+		if user == nil {
+			return nil, notFoundError()
+		}
+		return user, nil
+	}
+
+	user, err := c.userDaoInterface.GetUserIncludingDeleted(userId)
+	if err != nil {
+		if err.Code == http.StatusNotFound {
+			c.store(userId, nil, generation)
+		}
+		return nil, err
+	}
+
+	c.store(userId, user, generation)
+
+	return user, nil
+}
+
+func (c *cachedUserDao) CreateUser(user *User, actor string) (*User, *util.ResponseError) {
+
+	created, err := c.userDaoInterface.CreateUser(user, actor)
+	if created != nil {
+		// the id may have been looked up before the user existed
+		c.invalidate(int64(created.Id))
+	}
+
+	return created, err
+}
+
+func (c *cachedUserDao) UpdateUser(user *User, actor string) (*User, *util.ResponseError) {
+
+	defer c.invalidate(int64(user.Id))
+
+	return c.userDaoInterface.UpdateUser(user, actor)
+}
+
+func (c *cachedUserDao) DeleteUser(userId int64, version uint64, actor string) *util.ResponseError {
+
+	defer c.invalidate(userId)
+
+	return c.userDaoInterface.DeleteUser(userId, version, actor)
+}
+
+func (c *cachedUserDao) RestoreUser(userId int64, actor string) (*User, *util.ResponseError) {
+
+	defer c.invalidate(userId)
+
+	return c.userDaoInterface.RestoreUser(userId, actor)
+}
+
+func (c *cachedUserDao) Stats() *CacheStats {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	stats.Capacity = c.capacity
+
+	return &stats
+}
+
+// lookup returns a copy of the cached user, nil for a cached miss, and whether there was an entry.
+// Without one it returns the generation to store the user with.
+func (c *cachedUserDao) lookup(userId int64) (*User, bool, uint64) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, present := c.entries[userId]
+	if !present {
+		c.stats.Misses++
+		return nil, false, c.generation
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if !now().Before(entry.expiresAt) {
+		c.remove(element)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return nil, false, c.generation
+	}
+
+	c.order.MoveToFront(element)
+
+	if entry.user == nil {
+		c.stats.NegativeHits++
+		return nil, true, 0
+	}
+
+	c.stats.Hits++
+	user := *entry.user
+	return &user, true, 0
+}
+
+func (c *cachedUserDao) store(userId int64, user *User, generation uint64) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	entry := &cacheEntry{userId: userId, expiresAt: now().Add(c.ttl)}
+	if user == nil {
+		entry.expiresAt = now().Add(c.negativeTTL)
+	} else {
+		cached := *user
+		entry.user = &cached
+	}
+
+	if element, present := c.entries[userId]; present {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[userId] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *cachedUserDao) invalidate(userId int64) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if element, present := c.entries[userId]; present {
+		c.remove(element)
+	}
+}
+
+// remove must be called holding the lock.
+func (c *cachedUserDao) remove(element *list.Element) {
+
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).userId)
+}
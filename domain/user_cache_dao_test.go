@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func freezeTime(t *testing.T, at time.Time) *time.Time {
+
+	current := at
+	previous := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = previous })
+
+	return &current
+}
+
+func TestCachedUserDaoHitsAndMisses(t *testing.T) {
+
+	cache := newCachedUserDao(newUserDaoImpl(), 10, time.Minute, time.Second)
+	created, err := cache.CreateUser(&User{FirstName: "Cached", Email: "cached@domain.com"}, "creator")
+	assert.Nil(t, err)
+	userId := int64(created.Id)
+
+	user, err := cache.GetUser(userId)
+	assert.Nil(t, err)
+	user.FirstName = "Changed"
+
+	user, err = cache.GetUser(userId)
+	assert.Nil(t, err)
+	assert.Equal(t, "Cached", user.FirstName)
+
+	stats := cache.Stats()
+	assert.EqualValues(t, 1, stats.Misses)
+	assert.EqualValues(t, 1, stats.Hits)
+	assert.Equal(t, 1, stats.Size)
+	assert.Equal(t, 10, stats.Capacity)
+}
+
+func TestCachedUserDaoCachesNotFound(t *testing.T) {
+
+	clock := freezeTime(t, time.Now())
+	inner := newUserDaoImpl()
+	cache := newCachedUserDao(inner, 10, time.Minute, time.Second)
+
+	_, err := cache.GetUser(1)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	// created behind the cache, the miss is remembered until it expires
+	_, err = inner.CreateUser(&User{FirstName: "Late", Email: "late@domain.com"}, "creator")
+	assert.Nil(t, err)
+
+	_, err = cache.GetUser(1)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+	assert.EqualValues(t, 1, cache.Stats().NegativeHits)
+
+	*clock = clock.Add(time.Second)
+	user, err := cache.GetUser(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Late", user.FirstName)
+	assert.EqualValues(t, 1, cache.Stats().Expirations)
+}
+
+func TestCachedUserDaoExpiresEntries(t *testing.T) {
+
+	clock := freezeTime(t, time.Now())
+	inner := newUserDaoImpl(User{Id: 1, FirstName: "Before", Email: "expire@domain.com"})
+	cache := newCachedUserDao(inner, 10, time.Minute, time.Second)
+
+	_, err := cache.GetUser(1)
+	assert.Nil(t, err)
+
+	stored, _ := inner.GetUser(1)
+	stored.FirstName = "After"
+	_, err = inner.UpdateUser(stored, "editor")
+	assert.Nil(t, err)
+
+	user, _ := cache.GetUser(1)
+	assert.Equal(t, "Before", user.FirstName)
+
+	*clock = clock.Add(time.Minute)
+	user, _ = cache.GetUser(1)
+	assert.Equal(t, "After", user.FirstName)
+}
+
+func TestCachedUserDaoEvictsLeastRecentlyUsed(t *testing.T) {
+
+	inner := newUserDaoImpl(
+		User{Id: 1, Email: "one@domain.com"},
+		User{Id: 2, Email: "two@domain.com"},
+		User{Id: 3, Email: "three@domain.com"})
+	cache := newCachedUserDao(inner, 2, time.Minute, time.Second)
+
+	cache.GetUser(1)
+	cache.GetUser(2)
+	cache.GetUser(1)
+	cache.GetUser(3)
+
+	stats := cache.Stats()
+	assert.Equal(t, 2, stats.Size)
+	assert.EqualValues(t, 1, stats.Evictions)
+
+	cache.GetUser(1)
+	assert.EqualValues(t, 2, cache.Stats().Hits)
+
+	cache.GetUser(2)
+	assert.EqualValues(t, 4, cache.Stats().Misses)
+}
+
+func TestCachedUserDaoInvalidatesOnWrites(t *testing.T) {
+
+	cache := newCachedUserDao(newUserDaoImpl(), 10, time.Minute, time.Minute)
+	created, _ := cache.CreateUser(&User{FirstName: "Before", Email: "writes@domain.com"}, "creator")
+	userId := int64(created.Id)
+
+	user, _ := cache.GetUser(userId)
+	user.FirstName = "After"
+	updated, err := cache.UpdateUser(user, "editor")
+	assert.Nil(t, err)
+
+	user, _ = cache.GetUser(userId)
+	assert.Equal(t, "After", user.FirstName)
+
+	assert.Nil(t, cache.DeleteUser(userId, updated.Version, "remover"))
+
+	_, err = cache.GetUser(userId)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	user, err = cache.GetUserIncludingDeleted(userId)
+	assert.Nil(t, err)
+	assert.True(t, user.IsDeleted())
+
+	_, err = cache.RestoreUser(userId, "restorer")
+	assert.Nil(t, err)
+
+	user, err = cache.GetUser(userId)
+	assert.Nil(t, err)
+	assert.False(t, user.IsDeleted())
+}
+
+func TestUserCacheStatsWithoutCache(t *testing.T) {
+
+	previous := UserDao
+	UserDao = newUserDaoImpl()
+	defer func() { UserDao = previous }()
+
+	_, err := UserCacheStats()
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	ConfigureUserCache(10, time.Minute, time.Second)
+	stats, err := UserCacheStats()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, stats.Capacity)
+}
@@ -1,46 +1,434 @@
 package domain
 
 import (
+	"fmt"
 	"github.com/leandrotula/golangmicroservice/util"
 	"net/http"
+	"sort"
+	"sync"
 )
 
-var (
-	userData = map[int64]User{
-		1: {
-			Id:        1,
-			FirstName: "TestFirstName",
-			LastName:  "TestLastName",
-			Email:     "test@domain.com",
-		},
-	}
+const (
+	MemoryStore = "memory"
+	FileStore   = "file"
+	SqlStore    = "sqlite"
+)
 
+var (
 	UserDao userDaoInterface
 )
 
 func init() {
 
-	UserDao = &userDaoImpl{}
+	UserDao = newUserDaoImpl(User{
+		Id:        1,
+		FirstName: "TestFirstName",
+		LastName:  "TestLastName",
+		Email:     "test@domain.com",
+		Version:   1,
+	})
 }
 
+// userDaoInterface mutations receive the actor performing them, so they can be recorded in the
+// history of the user together with the change itself.
 type userDaoInterface interface {
 
 	GetUser(userId int64)(*User, *util.ResponseError)
+	GetUserIncludingDeleted(userId int64)(*User, *util.ResponseError)
+	CreateUser(user *User, actor string)(*User, *util.ResponseError)
+	UpdateUser(user *User, actor string)(*User, *util.ResponseError)
+	DeleteUser(userId int64, version uint64, actor string) *util.ResponseError
+	RestoreUser(userId int64, actor string)(*User, *util.ResponseError)
+	ListUsers(query UserQuery)([]User, string, *util.ResponseError)
+	GetUserHistory(userId int64)([]UserChange, *util.ResponseError)
+	SearchUsers(query string, limit int)([]UserMatch, *util.ResponseError)
+	ListChanges(afterId uint64, limit int)([]UserChange, *util.ResponseError)
+	LatestChangeId()(uint64, *util.ResponseError)
+}
+
+// ConfigureUserDao replaces the default in-memory UserDao with the store selected at startup.
+// An empty store keeps the current one. The api keys, the repository owners and the webhooks
+// are kept by the same store, so with the memory store all of them are lost on restart.
+func ConfigureUserDao(store string, location string) error {
+
+	switch store {
+	case "":
+		return nil
+	case MemoryStore:
+		UserDao = newUserDaoImpl()
+		return nil
+	case FileStore:
+		dao, err := newFileUserDao(location, defaultSnapshotInterval)
+		if err != nil {
+			return err
+		}
+		apiKeys, err := newApiKeyFileDao(location)
+		if err != nil {
+			return err
+		}
+		owners, err := newRepositoryOwnerFileDao(location)
+		if err != nil {
+			return err
+		}
+		webhooks, err := newWebhookFileDao(location)
+		if err != nil {
+			return err
+		}
+		UserDao = dao
+		ApiKeyDao = apiKeys
+		RepositoryOwnerDao = owners
+		WebhookDao = webhooks
+		return nil
+	case SqlStore:
+		dao, err := newUserSqlDao(sqliteDriver, location)
+		if err != nil {
+			return err
+		}
+		UserDao = dao
+		ApiKeyDao = newApiKeySqlDao(dao.db)
+		RepositoryOwnerDao = newRepositoryOwnerSqlDao(dao.db)
+		WebhookDao = newWebhookSqlDao(dao.db)
+		return nil
+	}
+
+	return fmt.Errorf("unknown user store %q", store)
 }
 
+// userRecord is a single mutation of the user data. Stores that need to keep track of the
+// changes (like the file store) receive every record through the journal before it is applied.
+type userRecord struct {
+	Op     string      `json:"op"`
+	User   *User       `json:"user,omitempty"`
+	UserId int64       `json:"user_id,omitempty"`
+	Change *UserChange `json:"change,omitempty"`
+	// PasswordHash travels apart because User never serializes it
+	PasswordHash string `json:"password_hash,omitempty"`
+}
+
+const (
+	putOperation = "put"
+	// deleteOperation is only found in logs written before users were soft deleted
+	deleteOperation = "delete"
+)
+
 type userDaoImpl struct {
+	mu           sync.RWMutex
+	users        map[int64]User
+	emails       map[string]int64
+	history      map[int64][]UserChange
+	index        *userIndex
+	lastId       uint64
+	lastChangeId uint64
+	journal      func(record userRecord) error
+}
+
+func newUserDaoImpl(users ...User) *userDaoImpl {
+
+	dao := &userDaoImpl{
+		users:   make(map[int64]User),
+		emails:  make(map[string]int64),
+		history: make(map[int64][]UserChange),
+		index:   newUserIndex(),
+	}
+	for i := range users {
+		dao.apply(userRecord{Op: putOperation, User: &users[i]})
+	}
+
+	return dao
+}
+
+func notFoundError() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "No user found",
+		Code:    http.StatusNotFound,
+	}
 }
 
 func(u *userDaoImpl) GetUser(userId int64)(*User, *util.ResponseError) {
 
-	user, present := userData[userId]
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, present := u.users[userId]
+
+	if !present || user.IsDeleted() {
+		return nil, notFoundError()
+	}
+
+	return &user, nil
+}
+
+func(u *userDaoImpl) GetUserIncludingDeleted(userId int64)(*User, *util.ResponseError) {
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, present := u.users[userId]
 
 	if !present {
+		return nil, notFoundError()
+	}
+
+	return &user, nil
+}
+
+func(u *userDaoImpl) CreateUser(user *User, actor string)(*User, *util.ResponseError) {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	created := *user
+	created.Email = NormalizeEmail(created.Email)
+	created.DeletedAt = nil
+	created.Password = ""
+	if created.Role == "" {
+		created.Role = DefaultRole
+	}
+	if created.Id == 0 {
+		created.Id = u.lastId + 1
+	}
+	created.Version = 1
+
+	if _, present := u.users[int64(created.Id)]; present {
 		return nil, &util.ResponseError{
-			Message: "No user found",
-			Code:    http.StatusNotFound,
+			Message: "User already exists",
+			Code:    http.StatusConflict,
 		}
 	}
 
-	return &user, nil
+	if u.emailTaken(created.Email, int64(created.Id)) {
+		return nil, emailTakenError(created.Email)
+	}
+
+	if err := u.commit(&created, newUserChange(CreatedAction, actor, nil, &created)); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateUser replaces the user if it is still at user.Version, zero skips the check.
+func(u *userDaoImpl) UpdateUser(user *User, actor string)(*User, *util.ResponseError) {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	current, present := u.users[int64(user.Id)]
+	if !present || current.IsDeleted() {
+		return nil, notFoundError()
+	}
+
+	if user.Version != 0 && user.Version != current.Version {
+		return nil, VersionMismatchError()
+	}
+
+	updated := *user
+	updated.Email = NormalizeEmail(updated.Email)
+	updated.Version = current.Version + 1
+	updated.DeletedAt = nil
+	updated.Password = ""
+	if updated.Role == "" {
+		updated.Role = current.Role
+	}
+
+	if u.emailTaken(updated.Email, int64(updated.Id)) {
+		return nil, emailTakenError(updated.Email)
+	}
+
+	if err := u.commit(&updated, newUserChange(UpdatedAction, actor, &current, &updated)); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteUser marks the user as deleted if it is still at the given version, zero skips the check.
+// Deleted users keep their email, so they can always be restored.
+func(u *userDaoImpl) DeleteUser(userId int64, version uint64, actor string) *util.ResponseError {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	current, present := u.users[userId]
+	if !present || current.IsDeleted() {
+		return notFoundError()
+	}
+
+	if version != 0 && version != current.Version {
+		return VersionMismatchError()
+	}
+
+	deleted := current
+	deletedAt := now()
+	deleted.DeletedAt = &deletedAt
+	deleted.Version = current.Version + 1
+
+	return u.commit(&deleted, newUserChange(DeletedAction, actor, &current, &deleted))
+}
+
+func(u *userDaoImpl) RestoreUser(userId int64, actor string)(*User, *util.ResponseError) {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	current, present := u.users[userId]
+	if !present {
+		return nil, notFoundError()
+	}
+
+	if !current.IsDeleted() {
+		return nil, notDeletedError()
+	}
+
+	restored := current
+	restored.DeletedAt = nil
+	restored.Version = current.Version + 1
+
+	if err := u.commit(&restored, newUserChange(RestoredAction, actor, &current, &restored)); err != nil {
+		return nil, err
+	}
+
+	return &restored, nil
+}
+
+func(u *userDaoImpl) ListUsers(query UserQuery)([]User, string, *util.ResponseError) {
+
+	cursor, err := query.cursor()
+	if err != nil {
+		return nil, "", err
+	}
+
+	u.mu.RLock()
+	users := make([]User, 0)
+	for _, user := range u.users {
+		if query.matches(&user) && query.afterCursor(cursor, &user) {
+			users = append(users, user)
+		}
+	}
+	u.mu.RUnlock()
+
+	sort.Slice(users, func(i, j int) bool {
+		return query.less(&users[i], &users[j])
+	})
+
+	results, next := query.page(users)
+	return results, next, nil
+}
+
+func(u *userDaoImpl) GetUserHistory(userId int64)([]UserChange, *util.ResponseError) {
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if _, present := u.users[userId]; !present {
+		return nil, notFoundError()
+	}
+
+	history := make([]UserChange, len(u.history[userId]))
+	copy(history, u.history[userId])
+
+	return history, nil
+}
+
+// ListChanges returns the changes of every user with an id after the given one, in id order.
+func(u *userDaoImpl) ListChanges(afterId uint64, limit int)([]UserChange, *util.ResponseError) {
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	changes := make([]UserChange, 0)
+	for _, history := range u.history {
+		for _, change := range history {
+			if change.Id > afterId {
+				changes = append(changes, change)
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Id < changes[j].Id })
+
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+
+	return changes, nil
+}
+
+func(u *userDaoImpl) LatestChangeId()(uint64, *util.ResponseError) {
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	return u.lastChangeId, nil
+}
+
+func(u *userDaoImpl) SearchUsers(query string, limit int)([]UserMatch, *util.ResponseError) {
+
+	return u.index.search(query, limit), nil
+}
+
+func(u *userDaoImpl) emailTaken(email string, userId int64) bool {
+
+	owner, present := u.emails[NormalizeEmail(email)]
+	return present && owner != userId
+}
+
+// commit hands the new state of the user and its change to the journal (if any) and applies them.
+// Callers must hold the write lock.
+func(u *userDaoImpl) commit(user *User, change *UserChange) *util.ResponseError {
+
+	change.Id = u.lastChangeId + 1
+	record := userRecord{Op: putOperation, User: user, Change: change, PasswordHash: user.PasswordHash}
+
+	if u.journal != nil {
+		if err := u.journal(record); err != nil {
+			return &util.ResponseError{
+				Message: "could not persist user data",
+				Code:    http.StatusInternalServerError,
+			}
+		}
+	}
+
+	u.apply(record)
+	return nil
+}
+
+func(u *userDaoImpl) apply(record userRecord) {
+
+	switch record.Op {
+	case putOperation:
+		if previous, present := u.users[int64(record.User.Id)]; present {
+			delete(u.emails, NormalizeEmail(previous.Email))
+		}
+		if record.PasswordHash != "" {
+			record.User.PasswordHash = record.PasswordHash
+		}
+		if record.User.Version == 0 {
+			// records written before users were versioned
+			record.User.Version = 1
+		}
+		if record.User.Role == "" {
+			// records written before users had roles
+			record.User.Role = DefaultRole
+		}
+		u.users[int64(record.User.Id)] = *record.User
+		u.emails[NormalizeEmail(record.User.Email)] = int64(record.User.Id)
+		u.index.put(*record.User)
+		if record.User.Id > u.lastId {
+			u.lastId = record.User.Id
+		}
+	case deleteOperation:
+		if previous, present := u.users[record.UserId]; present {
+			delete(u.emails, NormalizeEmail(previous.Email))
+		}
+		delete(u.users, record.UserId)
+		u.index.delete(record.UserId)
+	}
+
+	if record.Change != nil {
+		u.history[int64(record.Change.UserId)] = append(u.history[int64(record.Change.UserId)], *record.Change)
+		if record.Change.Id > u.lastChangeId {
+			u.lastChangeId = record.Change.Id
+		}
+	}
 }
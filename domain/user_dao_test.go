@@ -7,22 +7,97 @@ executing the others.
  */
 import (
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"testing"
 )
 
 func TestGetUserNoDataFound(t *testing.T) {
 
-	user, err := GetUser(0)
+	user, err := UserDao.GetUser(0)
 	assert.Nil(t, user, "user should be null due non existent data")
 	assert.NotNil(t, err, "Error message should not be null")
 }
 
 func TestGetUserFound(t *testing.T) {
 
-	user, err := GetUser(1)
+	user, err := UserDao.GetUser(1)
 	assert.Nil(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, "test@domain.com", user.Email)
 	assert.Equal(t, "TestFirstName", user.FirstName)
 	assert.Equal(t, "TestLastName", user.LastName)
 }
+
+func TestCreateUserAssignsId(t *testing.T) {
+
+	user, err := UserDao.CreateUser(&User{FirstName: "New", LastName: "User", Email: "new@domain.com"}, "tester")
+	assert.Nil(t, err)
+	assert.NotNil(t, user)
+	assert.NotEqual(t, uint64(0), user.Id)
+
+	found, err := UserDao.GetUser(int64(user.Id))
+	assert.Nil(t, err)
+	assert.Equal(t, "new@domain.com", found.Email)
+
+	assert.Nil(t, UserDao.DeleteUser(int64(user.Id), 0, "tester"))
+}
+
+func TestCreateUserConflict(t *testing.T) {
+
+	user, err := UserDao.CreateUser(&User{Id: 1, Email: "other@domain.com"}, "tester")
+	assert.Nil(t, user)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.Code)
+}
+
+func TestUpdateUserNotFound(t *testing.T) {
+
+	user, err := UserDao.UpdateUser(&User{Id: 999, Email: "missing@domain.com"}, "tester")
+	assert.Nil(t, user)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+}
+
+func TestDeleteUserNotFound(t *testing.T) {
+
+	err := UserDao.DeleteUser(999, 0, "tester")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+}
+
+func assertOptimisticConcurrency(t *testing.T, dao userDaoInterface) {
+
+	created, err := dao.CreateUser(&User{Email: "versioned@domain.com"}, "tester")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), created.Version)
+
+	first := *created
+	first.FirstName = "First writer"
+	updated, err := dao.UpdateUser(&first, "tester")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), updated.Version)
+
+	second := *created
+	second.FirstName = "Second writer"
+	user, err := dao.UpdateUser(&second, "tester")
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusPreconditionFailed, err.Code)
+
+	err = dao.DeleteUser(int64(created.Id), created.Version, "tester")
+	assert.Equal(t, http.StatusPreconditionFailed, err.Code)
+
+	assert.Nil(t, dao.DeleteUser(int64(created.Id), updated.Version, "tester"))
+}
+
+func TestOptimisticConcurrencyMemory(t *testing.T) {
+
+	assertOptimisticConcurrency(t, newUserDaoImpl())
+}
+
+func TestOptimisticConcurrencySql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertOptimisticConcurrency(t, dao)
+}
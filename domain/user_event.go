@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	UserCreatedEvent  = "user.created"
+	UserUpdatedEvent  = "user.updated"
+	UserDeletedEvent  = "user.deleted"
+	UserRestoredEvent = "user.restored"
+)
+
+// UserEvent is what downstream systems receive. Events are not stored on their own: every write
+// already records a UserChange in the same lock or transaction, so the history doubles as the
+// outbox and the event id is the id of the change.
+type UserEvent struct {
+	Id         uint64                 `json:"id"`
+	Type       string                 `json:"type"`
+	UserId     uint64                 `json:"user_id"`
+	Actor      string                 `json:"actor"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Changes    map[string]FieldChange `json:"changes,omitempty"`
+}
+
+func NewUserEvent(change UserChange) UserEvent {
+
+	return UserEvent{
+		Id:         change.Id,
+		Type:       "user." + change.Action,
+		UserId:     change.UserId,
+		Actor:      change.Actor,
+		OccurredAt: change.Timestamp,
+		Changes:    change.Changes,
+	}
+}
+
+func IsUserEventType(eventType string) bool {
+
+	switch eventType {
+	case UserCreatedEvent, UserUpdatedEvent, UserDeletedEvent, UserRestoredEvent:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	logFileName             = "users.log"
+	snapshotFileName        = "users.snapshot"
+	defaultSnapshotInterval = time.Minute
+)
+
+type userSnapshot struct {
+	Users     []User            `json:"users"`
+	Passwords map[uint64]string `json:"passwords,omitempty"`
+	History   []UserChange      `json:"history"`
+}
+
+// fileUserDao keeps the users in memory and persists every mutation to an append-only log.
+// The log is periodically compacted into a snapshot, and both are replayed on startup.
+type fileUserDao struct {
+	*userDaoImpl
+	dir     string
+	log     *os.File
+	pending int
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newFileUserDao(dir string, snapshotInterval time.Duration) (*fileUserDao, error) {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	dao := &fileUserDao{
+		userDaoImpl: newUserDaoImpl(),
+		dir:         dir,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	if err := dao.load(); err != nil {
+		return nil, err
+	}
+
+	logFile, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	dao.log = logFile
+	dao.journal = dao.append
+
+	go dao.snapshotLoop(snapshotInterval)
+
+	return dao, nil
+}
+
+func (f *fileUserDao) load() error {
+
+	snapshot, err := ioutil.ReadFile(filepath.Join(f.dir, snapshotFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if len(snapshot) > 0 {
+		var state userSnapshot
+		if snapshot[0] == '[' {
+			// snapshots written before the history was kept only hold the users
+			err = json.Unmarshal(snapshot, &state.Users)
+		} else {
+			err = json.Unmarshal(snapshot, &state)
+		}
+		if err != nil {
+			return err
+		}
+		for i := range state.Users {
+			f.apply(userRecord{Op: putOperation, User: &state.Users[i], PasswordHash: state.Passwords[state.Users[i].Id]})
+		}
+		for i := range state.History {
+			f.apply(userRecord{Change: &state.History[i]})
+		}
+	}
+
+	logName := filepath.Join(f.dir, logFileName)
+	logFile, err := os.Open(logName)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	var offset int64
+	reader := bufio.NewReader(logFile)
+	for {
+		line, readError := reader.ReadBytes('\n')
+		if readError == io.EOF {
+			if len(line) == 0 {
+				return nil
+			}
+			// the process died in the middle of a write, that record was never acknowledged
+			return os.Truncate(logName, offset)
+		}
+		if readError != nil {
+			return readError
+		}
+
+		var record userRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			// a torn last line was never acknowledged either, anything before the end is corruption
+			if _, peekError := reader.Peek(1); peekError == io.EOF {
+				return os.Truncate(logName, offset)
+			}
+			return err
+		}
+		f.apply(record)
+		f.pending++
+		offset += int64(len(line))
+	}
+}
+
+// append is the journal of the embedded store, so it always runs under its write lock.
+func (f *fileUserDao) append(record userRecord) error {
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.log.Stat()
+	if err != nil {
+		return err
+	}
+
+	_, err = f.log.Write(append(line, '\n'))
+	if err == nil {
+		err = f.log.Sync()
+	}
+	if err != nil {
+		// drop whatever part of the record reached the file, the next record must start on a fresh line
+		_ = f.log.Truncate(info.Size())
+		return err
+	}
+
+	f.pending++
+	return nil
+}
+
+// Snapshot writes the current users to the snapshot file and truncates the log.
+func (f *fileUserDao) Snapshot() error {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.pending == 0 {
+		return nil
+	}
+
+	state := userSnapshot{Users: make([]User, 0, len(f.users)), Passwords: make(map[uint64]string)}
+	for _, user := range f.users {
+		state.Users = append(state.Users, user)
+		if user.PasswordHash != "" {
+			state.Passwords[user.Id] = user.PasswordHash
+		}
+	}
+	for _, changes := range f.history {
+		state.History = append(state.History, changes...)
+	}
+	sort.Slice(state.History, func(i, j int) bool {
+		return state.History[i].Id < state.History[j].Id
+	})
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmpName := filepath.Join(f.dir, snapshotFileName+".tmp")
+	if err := ioutil.WriteFile(tmpName, data, 0644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpName, filepath.Join(f.dir, snapshotFileName)); err != nil {
+		return err
+	}
+
+	if err := f.log.Truncate(0); err != nil {
+		return err
+	}
+
+	f.pending = 0
+	return nil
+}
+
+func (f *fileUserDao) snapshotLoop(interval time.Duration) {
+
+	defer close(f.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = f.Snapshot()
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// Close stops the periodic snapshots, takes a final one and releases the log file.
+func (f *fileUserDao) Close() error {
+
+	close(f.stop)
+	<-f.done
+
+	if err := f.Snapshot(); err != nil {
+		return err
+	}
+
+	return f.log.Close()
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestFileDao(t *testing.T, dir string) *fileUserDao {
+
+	dao, err := newFileUserDao(dir, time.Hour)
+	assert.Nil(t, err)
+	assert.NotNil(t, dao)
+	return dao
+}
+
+func TestFileDaoReplaysLogOnStartup(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "users")
+	defer os.RemoveAll(dir)
+
+	dao := newTestFileDao(t, dir)
+	created, err := dao.CreateUser(&User{FirstName: "Log", Email: "log@domain.com", PasswordHash: "hash"}, "tester")
+	assert.Nil(t, err)
+	_, err = dao.UpdateUser(&User{Id: created.Id, FirstName: "Replayed", Email: "log@domain.com", PasswordHash: "hash"}, "tester")
+	assert.Nil(t, err)
+	other, _ := dao.CreateUser(&User{Email: "gone@domain.com"}, "tester")
+	assert.Nil(t, dao.DeleteUser(int64(other.Id), 0, "tester"))
+	dao.log.Close()
+
+	reopened := newTestFileDao(t, dir)
+	defer reopened.Close()
+
+	user, err := reopened.GetUser(int64(created.Id))
+	assert.Nil(t, err)
+	assert.Equal(t, "Replayed", user.FirstName)
+	assert.Equal(t, "hash", user.PasswordHash)
+
+	user, err = reopened.GetUser(int64(other.Id))
+	assert.Nil(t, user)
+	assert.NotNil(t, err)
+
+	history, err := reopened.GetUserHistory(int64(created.Id))
+	assert.Nil(t, err)
+	assert.Len(t, history, 2)
+}
+
+func TestFileDaoSnapshotTruncatesLog(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "users")
+	defer os.RemoveAll(dir)
+
+	dao := newTestFileDao(t, dir)
+	created, _ := dao.CreateUser(&User{Email: "snapshot@domain.com", PasswordHash: "hash"}, "tester")
+	assert.Nil(t, dao.Close())
+
+	info, err := os.Stat(filepath.Join(dir, logFileName))
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, info.Size())
+
+	reopened := newTestFileDao(t, dir)
+	defer reopened.Close()
+
+	user, userErr := reopened.GetUser(int64(created.Id))
+	assert.Nil(t, userErr)
+	assert.Equal(t, "snapshot@domain.com", user.Email)
+	assert.Equal(t, "hash", user.PasswordHash)
+
+	history, userErr := reopened.GetUserHistory(int64(created.Id))
+	assert.Nil(t, userErr)
+	assert.Len(t, history, 1)
+}
+
+func TestFileDaoIgnoresPartialRecord(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "users")
+	defer os.RemoveAll(dir)
+
+	dao := newTestFileDao(t, dir)
+	created, _ := dao.CreateUser(&User{Email: "partial@domain.com"}, "tester")
+	dao.log.WriteString(`{"op":"put","user":{"id":`)
+	dao.log.Close()
+
+	reopened := newTestFileDao(t, dir)
+	defer reopened.Close()
+
+	_, err := reopened.GetUser(int64(created.Id))
+	assert.Nil(t, err)
+
+	next, err := reopened.CreateUser(&User{Email: "after@domain.com"}, "tester")
+	assert.Nil(t, err)
+	reopened.log.Close()
+
+	again := newTestFileDao(t, dir)
+	defer again.Close()
+
+	_, err = again.GetUser(int64(next.Id))
+	assert.Nil(t, err)
+}
+
+func TestFileDaoIgnoresTornLastLine(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "users")
+	defer os.RemoveAll(dir)
+
+	dao := newTestFileDao(t, dir)
+	created, _ := dao.CreateUser(&User{Email: "torn@domain.com"}, "tester")
+	dao.log.WriteString("{\"op\":\"put\",\"user\":{\"id\":\n")
+	dao.log.Close()
+
+	reopened := newTestFileDao(t, dir)
+	defer reopened.Close()
+
+	_, err := reopened.GetUser(int64(created.Id))
+	assert.Nil(t, err)
+
+	data, _ := ioutil.ReadFile(filepath.Join(dir, logFileName))
+	assert.NotContains(t, string(data), "{\"op\":\"put\",\"user\":{\"id\":\n")
+}
+
+func TestFileDaoRejectsCorruptRecordBeforeTheEnd(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "users")
+	defer os.RemoveAll(dir)
+
+	dao := newTestFileDao(t, dir)
+	dao.log.WriteString("not json\n")
+	dao.CreateUser(&User{Email: "later@domain.com"}, "tester")
+	dao.log.Close()
+
+	_, err := newFileUserDao(dir, time.Hour)
+	assert.NotNil(t, err)
+}
+
+func TestFileDaoFailedWriteIsNotApplied(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "users")
+	defer os.RemoveAll(dir)
+
+	dao := newTestFileDao(t, dir)
+	dao.log.Close()
+	dao.log, _ = os.Open(filepath.Join(dir, logFileName))
+
+	_, err := dao.CreateUser(&User{Email: "lost@domain.com"}, "tester")
+	assert.NotNil(t, err)
+
+	assert.False(t, dao.emailTaken("lost@domain.com", 0))
+	assert.EqualValues(t, 0, dao.pending)
+	dao.log.Close()
+}
+
+func TestFileDaoConcurrentWrites(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "users")
+	defer os.RemoveAll(dir)
+
+	dao := newTestFileDao(t, dir)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			created, err := dao.CreateUser(&User{Email: fmt.Sprintf("concurrent%d@domain.com", i)}, "tester")
+			assert.Nil(t, err)
+			_, err = dao.GetUser(int64(created.Id))
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+	dao.log.Close()
+
+	reopened := newTestFileDao(t, dir)
+	defer reopened.Close()
+	assert.Len(t, reopened.users, 20)
+}
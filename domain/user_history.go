@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	CreatedAction  = "created"
+	UpdatedAction  = "updated"
+	DeletedAction  = "deleted"
+	RestoredAction = "restored"
+)
+
+const redacted = "[redacted]"
+
+var now = func() time.Time {
+	return time.Now().UTC()
+}
+
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// UserChange is one entry of the history of a user: who did what and which fields changed.
+type UserChange struct {
+	Id        uint64                 `json:"id"`
+	UserId    uint64                 `json:"user_id"`
+	Action    string                 `json:"action"`
+	Actor     string                 `json:"actor"`
+	Timestamp time.Time              `json:"timestamp"`
+	Changes   map[string]FieldChange `json:"changes,omitempty"`
+}
+
+func newUserChange(action string, actor string, before *User, after *User) *UserChange {
+
+	return &UserChange{
+		UserId:    after.Id,
+		Action:    action,
+		Actor:     actor,
+		Timestamp: now(),
+		Changes:   diffUsers(before, after),
+	}
+}
+
+// diffUsers lists the editable fields that differ, before is nil for a new user.
+func diffUsers(before *User, after *User) map[string]FieldChange {
+
+	if before == nil {
+		before = &User{}
+	}
+
+	fields := []struct {
+		name   string
+		before string
+		after  string
+	}{
+		{"first_name", before.FirstName, after.FirstName},
+		{"last_name", before.LastName, after.LastName},
+		{"email", before.Email, after.Email},
+		{"role", before.Role, after.Role},
+	}
+
+	changes := make(map[string]FieldChange)
+	for _, field := range fields {
+		if field.before != field.after {
+			changes[field.name] = FieldChange{From: field.before, To: field.after}
+		}
+	}
+
+	if before.PasswordHash != after.PasswordHash {
+		changes["password"] = FieldChange{From: redacted, To: redacted}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return changes
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func assertSoftDeleteAndHistory(t *testing.T, dao userDaoInterface) {
+
+	created, err := dao.CreateUser(&User{FirstName: "Before", Email: "history@domain.com"}, "creator")
+	assert.Nil(t, err)
+	userId := int64(created.Id)
+
+	created.FirstName = "After"
+	updated, err := dao.UpdateUser(created, "editor")
+	assert.Nil(t, err)
+
+	assert.Nil(t, dao.DeleteUser(userId, updated.Version, "remover"))
+
+	user, err := dao.GetUser(userId)
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	user, err = dao.GetUserIncludingDeleted(userId)
+	assert.Nil(t, err)
+	assert.True(t, user.IsDeleted())
+
+	query := UserQuery{Email: "history@domain.com"}
+	assert.Nil(t, query.Validate())
+	users, _, _ := dao.ListUsers(query)
+	assert.Len(t, users, 0)
+
+	query.IncludeDeleted = true
+	users, _, _ = dao.ListUsers(query)
+	assert.Len(t, users, 1)
+
+	_, err = dao.UpdateUser(user, "editor")
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	restored, err := dao.RestoreUser(userId, "restorer")
+	assert.Nil(t, err)
+	assert.False(t, restored.IsDeleted())
+	assert.Equal(t, "After", restored.FirstName)
+
+	_, err = dao.RestoreUser(userId, "restorer")
+	assert.Equal(t, http.StatusConflict, err.Code)
+
+	history, err := dao.GetUserHistory(userId)
+	assert.Nil(t, err)
+	assert.Len(t, history, 4)
+
+	assert.Equal(t, CreatedAction, history[0].Action)
+	assert.Equal(t, "creator", history[0].Actor)
+	assert.Equal(t, FieldChange{From: "", To: "history@domain.com"}, history[0].Changes["email"])
+
+	assert.Equal(t, UpdatedAction, history[1].Action)
+	assert.Equal(t, "editor", history[1].Actor)
+	assert.Equal(t, FieldChange{From: "Before", To: "After"}, history[1].Changes["first_name"])
+	assert.Len(t, history[1].Changes, 1)
+
+	assert.Equal(t, DeletedAction, history[2].Action)
+	assert.Equal(t, "remover", history[2].Actor)
+	assert.Equal(t, RestoredAction, history[3].Action)
+	assert.False(t, history[3].Timestamp.IsZero())
+
+	_, err = dao.GetUserHistory(9999)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	changes, err := dao.ListChanges(history[0].Id, 2)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, history[1].Id, changes[0].Id)
+	assert.Equal(t, UserDeletedEvent, NewUserEvent(changes[1]).Type)
+
+	latest, err := dao.LatestChangeId()
+	assert.Nil(t, err)
+	assert.Equal(t, history[3].Id, latest)
+}
+
+func TestSoftDeleteAndHistoryMemory(t *testing.T) {
+
+	assertSoftDeleteAndHistory(t, newUserDaoImpl())
+}
+
+func TestSoftDeleteAndHistorySql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertSoftDeleteAndHistory(t, dao)
+}
@@ -0,0 +1,40 @@
+package domain
+
+const (
+	ImportCreated   = "created"
+	ImportDuplicate = "skipped_duplicate"
+	ImportInvalid   = "invalid"
+)
+
+// ImportRow is the outcome of a single record of an import. Record counts the decoded records
+// from 1, not the lines of the file: the csv header, blank ndjson lines and line breaks inside
+// quoted csv fields are not counted.
+type ImportRow struct {
+	Record int    `json:"record"`
+	Status string `json:"status"`
+	Id     uint64 `json:"id,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun     bool        `json:"dry_run"`
+	Created    int         `json:"created"`
+	Duplicates int         `json:"skipped_duplicates"`
+	Invalid    int         `json:"invalid"`
+	Rows       []ImportRow `json:"rows"`
+}
+
+func (r *ImportReport) Add(row ImportRow) {
+
+	switch row.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportDuplicate:
+		r.Duplicates++
+	case ImportInvalid:
+		r.Invalid++
+	}
+
+	r.Rows = append(r.Rows, row)
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"strings"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+var sortableUserFields = map[string]func(u *User) string{
+	"first_name": func(u *User) string { return u.FirstName },
+	"last_name":  func(u *User) string { return u.LastName },
+	"email":      func(u *User) string { return u.Email },
+}
+
+// UserQuery holds the filters, ordering and page position of a user listing. Filters are exact
+// matches ignoring case, Sort is one of the json field names, prefixed with '-' for descending order.
+// Deleted users are left out unless IncludeDeleted is set.
+type UserQuery struct {
+	IncludeDeleted bool
+	FirstName      string
+	LastName       string
+	Email          string
+	Sort           string
+	Limit          int
+	Cursor         string
+}
+
+type UserList struct {
+	Results []User `json:"results"`
+	Next    string `json:"next,omitempty"`
+}
+
+// userCursor is the position after the last user of a page. It carries the sort it was produced
+// for, so it cannot be reused with a different ordering.
+type userCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	Id    uint64 `json:"i"`
+}
+
+func (q *UserQuery) Validate() *util.ResponseError {
+
+	if q.Limit <= 0 {
+		q.Limit = DefaultListLimit
+	}
+
+	if q.Limit > MaxListLimit {
+		q.Limit = MaxListLimit
+	}
+
+	if q.Sort == "" {
+		q.Sort = "id"
+	}
+
+	if field := q.sortField(); field != "id" && sortableUserFields[field] == nil {
+		return &util.ResponseError{
+			Message: "invalid sort field",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if _, err := q.cursor(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q *UserQuery) sortField() string {
+
+	return strings.TrimPrefix(q.Sort, "-")
+}
+
+func (q *UserQuery) descending() bool {
+
+	return strings.HasPrefix(q.Sort, "-")
+}
+
+func (q *UserQuery) sortValue(user *User) string {
+
+	if value := sortableUserFields[q.sortField()]; value != nil {
+		return value(user)
+	}
+
+	return ""
+}
+
+func (q *UserQuery) matches(user *User) bool {
+
+	return (q.IncludeDeleted || !user.IsDeleted()) &&
+		matchesFilter(q.FirstName, user.FirstName) &&
+		matchesFilter(q.LastName, user.LastName) &&
+		matchesFilter(q.Email, user.Email)
+}
+
+func matchesFilter(filter string, value string) bool {
+
+	return filter == "" || strings.EqualFold(filter, value)
+}
+
+// less reports whether a goes before b in the requested order, ties are broken by id.
+func (q *UserQuery) less(a *User, b *User) bool {
+
+	valueA, valueB := q.sortValue(a), q.sortValue(b)
+
+	if valueA == valueB {
+		if q.descending() {
+			return a.Id > b.Id
+		}
+		return a.Id < b.Id
+	}
+
+	if q.descending() {
+		return valueA > valueB
+	}
+	return valueA < valueB
+}
+
+func (q *UserQuery) cursor() (*userCursor, *util.ResponseError) {
+
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	invalid := &util.ResponseError{
+		Message: "invalid cursor",
+		Code:    http.StatusBadRequest,
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var cursor userCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != q.Sort {
+		return nil, invalid
+	}
+
+	return &cursor, nil
+}
+
+func (q *UserQuery) nextCursor(last *User) string {
+
+	data, _ := json.Marshal(userCursor{Sort: q.Sort, Value: q.sortValue(last), Id: last.Id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// afterCursor reports whether user comes after the position of the cursor in the requested order.
+func (q *UserQuery) afterCursor(cursor *userCursor, user *User) bool {
+
+	if cursor == nil {
+		return true
+	}
+
+	value := q.sortValue(user)
+	if value == cursor.Value {
+		if q.descending() {
+			return user.Id < cursor.Id
+		}
+		return user.Id > cursor.Id
+	}
+
+	if q.descending() {
+		return value < cursor.Value
+	}
+	return value > cursor.Value
+}
+
+// page cuts the page out of users, which must already be filtered, sorted and past the cursor.
+func (q *UserQuery) page(users []User) ([]User, string) {
+
+	if len(users) <= q.Limit {
+		return users, ""
+	}
+
+	users = users[:q.Limit]
+	return users, q.nextCursor(&users[len(users)-1])
+}
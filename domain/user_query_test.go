@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func seedListUsers(t *testing.T, dao userDaoInterface) {
+
+	lastNames := []string{"Smith", "Adams", "Smith", "Brown", "Adams"}
+	for i, lastName := range lastNames {
+		_, err := dao.CreateUser(&User{
+			FirstName: fmt.Sprintf("Name%d", i),
+			LastName:  lastName,
+			Email:     fmt.Sprintf("user%d@domain.com", i),
+		}, "tester")
+		assert.Nil(t, err)
+	}
+}
+
+func collectPages(t *testing.T, dao userDaoInterface, query UserQuery) []User {
+
+	var all []User
+	for {
+		assert.Nil(t, query.Validate())
+		users, next, err := dao.ListUsers(query)
+		assert.Nil(t, err)
+		assert.True(t, len(users) <= query.Limit)
+		all = append(all, users...)
+		if next == "" {
+			return all
+		}
+		query.Cursor = next
+	}
+}
+
+func assertListing(t *testing.T, dao userDaoInterface) {
+
+	seedListUsers(t, dao)
+
+	users := collectPages(t, dao, UserQuery{Limit: 2})
+	assert.Len(t, users, 5)
+	for i := 1; i < len(users); i++ {
+		assert.True(t, users[i-1].Id < users[i].Id)
+	}
+
+	users = collectPages(t, dao, UserQuery{Sort: "-last_name", Limit: 2})
+	assert.Len(t, users, 5)
+	assert.Equal(t, "Smith", users[0].LastName)
+	assert.Equal(t, "Smith", users[1].LastName)
+	assert.True(t, users[0].Id > users[1].Id)
+	assert.Equal(t, "Adams", users[4].LastName)
+
+	users = collectPages(t, dao, UserQuery{LastName: "adams", Limit: 1})
+	assert.Len(t, users, 2)
+	for _, user := range users {
+		assert.Equal(t, "Adams", user.LastName)
+	}
+}
+
+func TestListUsersMemory(t *testing.T) {
+
+	assertListing(t, newUserDaoImpl())
+}
+
+func TestListUsersSql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertListing(t, dao)
+}
+
+func TestListUsersCursorForAnotherSort(t *testing.T) {
+
+	dao := newUserDaoImpl()
+	seedListUsers(t, dao)
+
+	query := UserQuery{Limit: 2}
+	assert.Nil(t, query.Validate())
+	_, next, _ := dao.ListUsers(query)
+	assert.NotEqual(t, "", next)
+
+	query = UserQuery{Sort: "email", Cursor: next}
+	err := query.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+}
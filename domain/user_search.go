@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	DefaultSearchLimit = 10
+
+	exactMatchScore  = 3
+	prefixMatchScore = 2
+	fuzzyMatchScore  = 1
+)
+
+type UserMatch struct {
+	User  User `json:"user"`
+	Score int  `json:"score"`
+}
+
+// userIndex is an inverted index from the terms of the name and email of every visible user to
+// their ids. The terms are also kept sorted, so prefixes can be looked up with a binary search.
+type userIndex struct {
+	mu       sync.RWMutex
+	postings map[string]map[int64]bool
+	terms    []string
+	users    map[int64]User
+	versions map[int64]uint64
+}
+
+func newUserIndex() *userIndex {
+
+	return &userIndex{
+		postings: make(map[string]map[int64]bool),
+		users:    make(map[int64]User),
+		versions: make(map[int64]uint64),
+	}
+}
+
+// tokenize splits names and emails in lower case terms: "John.Doe@Mail.com" gives
+// john.doe@mail.com, john.doe, john, doe, mail.com, mail and com.
+func tokenize(values ...string) []string {
+
+	seen := make(map[string]bool)
+	var terms []string
+
+	add := func(term string) {
+		if term != "" && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for _, value := range values {
+
+		value = strings.ToLower(strings.TrimSpace(value))
+		if strings.Contains(value, "@") {
+			add(value)
+			parts := strings.SplitN(value, "@", 2)
+			add(parts[0])
+			add(parts[1])
+		}
+
+		for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			add(word)
+		}
+	}
+
+	return terms
+}
+
+// put indexes the user, replacing what was indexed for it before. Deleted users are only removed,
+// and versions older than the indexed one are ignored, as writers may reach here out of order.
+func (idx *userIndex) put(user User) {
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if user.Version < idx.versions[int64(user.Id)] {
+		return
+	}
+	idx.versions[int64(user.Id)] = user.Version
+
+	idx.remove(int64(user.Id))
+
+	if user.IsDeleted() {
+		return
+	}
+
+	idx.users[int64(user.Id)] = user
+	for _, term := range tokenize(user.FirstName, user.LastName, user.Email) {
+
+		ids, present := idx.postings[term]
+		if !present {
+			ids = make(map[int64]bool)
+			idx.postings[term] = ids
+
+			position := sort.SearchStrings(idx.terms, term)
+			idx.terms = append(idx.terms, "")
+			copy(idx.terms[position+1:], idx.terms[position:])
+			idx.terms[position] = term
+		}
+		ids[int64(user.Id)] = true
+	}
+}
+
+func (idx *userIndex) delete(userId int64) {
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(userId)
+	delete(idx.versions, userId)
+}
+
+// remove must be called with the write lock held.
+func (idx *userIndex) remove(userId int64) {
+
+	user, present := idx.users[userId]
+	if !present {
+		return
+	}
+
+	delete(idx.users, userId)
+	for _, term := range tokenize(user.FirstName, user.LastName, user.Email) {
+
+		ids := idx.postings[term]
+		delete(ids, userId)
+		if len(ids) == 0 {
+			delete(idx.postings, term)
+			position := sort.SearchStrings(idx.terms, term)
+			idx.terms = append(idx.terms[:position], idx.terms[position+1:]...)
+		}
+	}
+}
+
+// search scores every user against each term of the query: an exact term is worth more than a
+// prefix, which is worth more than a term within a small edit distance. Each query term counts
+// once per user with its best score, and the highest totals come first.
+func (idx *userIndex) search(query string, limit int) []UserMatch {
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	scores := make(map[int64]int)
+	for _, queryTerm := range tokenize(query) {
+
+		best := make(map[int64]int)
+		award := func(term string, score int) {
+			for id := range idx.postings[term] {
+				if score > best[id] {
+					best[id] = score
+				}
+			}
+		}
+
+		award(queryTerm, exactMatchScore)
+
+		position := sort.SearchStrings(idx.terms, queryTerm)
+		for i := position; i < len(idx.terms) && strings.HasPrefix(idx.terms[i], queryTerm); i++ {
+			if idx.terms[i] != queryTerm {
+				award(idx.terms[i], prefixMatchScore)
+			}
+		}
+
+		maxDistance := allowedDistance(queryTerm)
+		for _, term := range idx.terms {
+			if maxDistance > 0 && withinDistance(queryTerm, term, maxDistance) {
+				award(term, fuzzyMatchScore)
+			}
+		}
+
+		for id, score := range best {
+			scores[id] += score
+		}
+	}
+
+	matches := make([]UserMatch, 0, len(scores))
+	for id, score := range scores {
+		matches = append(matches, UserMatch{User: idx.users[id], Score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score == matches[j].Score {
+			return matches[i].User.Id < matches[j].User.Id
+		}
+		return matches[i].Score > matches[j].Score
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+// allowedDistance keeps typo tolerance proportional to the length of the term, short terms
+// would match almost anything otherwise.
+func allowedDistance(term string) int {
+
+	switch length := len([]rune(term)); {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// withinDistance reports whether the Levenshtein distance between a and b is at most max.
+func withinDistance(a string, b string, max int) bool {
+
+	first, second := []rune(a), []rune(b)
+	if diff := len(first) - len(second); diff > max || -diff > max {
+		return false
+	}
+
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(second); j++ {
+
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if current[j] < rowMin {
+				rowMin = current[j]
+			}
+		}
+
+		if rowMin > max {
+			return false
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(second)] <= max
+}
+
+func minInt(values ...int) int {
+
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+
+	return min
+}
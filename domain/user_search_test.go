@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+
+	terms := tokenize("Mary-Jane", "John.Doe@Mail.com")
+	assert.ElementsMatch(t, []string{"mary", "jane", "john.doe@mail.com", "john.doe", "mail.com",
+		"john", "doe", "mail", "com"}, terms)
+}
+
+func TestWithinDistance(t *testing.T) {
+
+	assert.True(t, withinDistance("smith", "smith", 1))
+	assert.True(t, withinDistance("smith", "smyth", 1))
+	assert.True(t, withinDistance("smith", "smit", 1))
+	assert.False(t, withinDistance("smith", "smythe", 1))
+	assert.True(t, withinDistance("johnathan", "jonathon", 2))
+}
+
+func assertSearch(t *testing.T, dao userDaoInterface) {
+
+	alice, _ := dao.CreateUser(&User{FirstName: "Alice", LastName: "Smith", Email: "alice.smith@corp.com"}, "tester")
+	alan, _ := dao.CreateUser(&User{FirstName: "Alan", LastName: "Smithers", Email: "alan@example.com"}, "tester")
+	bob, _ := dao.CreateUser(&User{FirstName: "Bob", LastName: "Smyth", Email: "bob@corp.com"}, "tester")
+
+	matches, err := dao.SearchUsers("smith", 10)
+	assert.Nil(t, err)
+	assert.Len(t, matches, 3)
+	assert.Equal(t, alice.Id, matches[0].User.Id)
+	assert.Equal(t, alan.Id, matches[1].User.Id)
+	assert.Equal(t, bob.Id, matches[2].User.Id)
+
+	matches, _ = dao.SearchUsers("al", 10)
+	assert.Len(t, matches, 2)
+
+	matches, _ = dao.SearchUsers("alice.smi", 10)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, alice.Id, matches[0].User.Id)
+
+	matches, _ = dao.SearchUsers("alice smith", 10)
+	assert.Equal(t, alice.Id, matches[0].User.Id)
+	assert.True(t, matches[0].Score > matches[1].Score)
+
+	bob.LastName = "Jones"
+	_, err = dao.UpdateUser(bob, "tester")
+	assert.Nil(t, err)
+	matches, _ = dao.SearchUsers("smyth", 10)
+	for _, match := range matches {
+		assert.NotEqual(t, bob.Id, match.User.Id)
+	}
+
+	assert.Nil(t, dao.DeleteUser(int64(alice.Id), 0, "tester"))
+	matches, _ = dao.SearchUsers("alice", 10)
+	assert.Len(t, matches, 0)
+
+	_, err = dao.RestoreUser(int64(alice.Id), "tester")
+	assert.Nil(t, err)
+	matches, _ = dao.SearchUsers("alice", 1)
+	assert.Len(t, matches, 1)
+}
+
+func TestSearchMemory(t *testing.T) {
+
+	assertSearch(t, newUserDaoImpl())
+}
+
+func TestSearchSql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertSearch(t, dao)
+}
@@ -0,0 +1,518 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/leandrotula/golangmicroservice/util"
+	_ "github.com/mattn/go-sqlite3"
+	"net/http"
+	"strings"
+)
+
+const (
+	sqliteDriver = "sqlite3"
+	userColumns  = "id, first_name, last_name, email, version, deleted_at, password_hash, role"
+)
+
+// userSqlDao keeps a search index of the users in memory, it is loaded at startup and updated
+// after every committed write.
+type userSqlDao struct {
+	db    *sql.DB
+	index *userIndex
+}
+
+func newUserSqlDao(driver string, dataSource string) (*userSqlDao, error) {
+
+	db, err := sql.Open(driver, dataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	if driver == sqliteDriver {
+		// sqlite only supports one writer, serializing the connections avoids "database is locked" errors
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	dao := &userSqlDao{db: db, index: newUserIndex()}
+	if err := dao.loadIndex(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return dao, nil
+}
+
+func (s *userSqlDao) loadIndex() error {
+
+	rows, err := s.db.Query(`SELECT ` + userColumns + ` FROM users WHERE deleted_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user User
+		if err := scanUser(rows, &user); err != nil {
+			return err
+		}
+		s.index.put(user)
+	}
+
+	return rows.Err()
+}
+
+func (s *userSqlDao) SearchUsers(query string, limit int) ([]UserMatch, *util.ResponseError) {
+
+	return s.index.search(query, limit), nil
+}
+
+func (s *userSqlDao) GetUser(userId int64) (*User, *util.ResponseError) {
+
+	return findUser(s.db, userId, false)
+}
+
+func (s *userSqlDao) GetUserIncludingDeleted(userId int64) (*User, *util.ResponseError) {
+
+	return findUser(s.db, userId, true)
+}
+
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func findUser(db queryRower, userId int64, includeDeleted bool) (*User, *util.ResponseError) {
+
+	var user User
+	row := db.QueryRow(`SELECT `+userColumns+` FROM users WHERE id = ?`, userId)
+
+	if err := scanUser(row, &user); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, notFoundError()
+		}
+
+		return nil, databaseError()
+	}
+
+	if user.IsDeleted() && !includeDeleted {
+		return nil, notFoundError()
+	}
+
+	return &user, nil
+}
+
+func scanUser(row interface{ Scan(dest ...interface{}) error }, user *User) error {
+
+	var deletedAt sql.NullTime
+	if err := row.Scan(&user.Id, &user.FirstName, &user.LastName, &user.Email, &user.Version, &deletedAt, &user.PasswordHash, &user.Role); err != nil {
+		return err
+	}
+
+	user.DeletedAt = nil
+	if deletedAt.Valid {
+		user.DeletedAt = &deletedAt.Time
+	}
+
+	return nil
+}
+
+func (s *userSqlDao) CreateUser(user *User, actor string) (*User, *util.ResponseError) {
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer tx.Rollback()
+
+	created := *user
+	created.Email = NormalizeEmail(created.Email)
+	created.Version = 1
+	created.DeletedAt = nil
+	created.Password = ""
+	if created.Role == "" {
+		created.Role = DefaultRole
+	}
+	if created.Id != 0 {
+
+		var exists int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM users WHERE id = ?`, created.Id).Scan(&exists)
+		if err != nil {
+			return nil, databaseError()
+		}
+
+		if exists > 0 {
+			return nil, &util.ResponseError{
+				Message: "User already exists",
+				Code:    http.StatusConflict,
+			}
+		}
+	}
+
+	if responseError := checkEmailAvailable(tx, created.Email, int64(created.Id)); responseError != nil {
+		return nil, responseError
+	}
+
+	var id interface{}
+	if created.Id != 0 {
+		id = created.Id
+	}
+
+	result, err := tx.Exec(`INSERT INTO users (id, first_name, last_name, email, version, password_hash, role)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		id, created.FirstName, created.LastName, created.Email, created.Version, created.PasswordHash, created.Role)
+	if err != nil {
+		return nil, databaseError()
+	}
+
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		return nil, databaseError()
+	}
+	created.Id = uint64(lastId)
+
+	if responseError := recordChange(tx, newUserChange(CreatedAction, actor, nil, &created)); responseError != nil {
+		return nil, responseError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, databaseError()
+	}
+	s.index.put(created)
+
+	return &created, nil
+}
+
+func (s *userSqlDao) UpdateUser(user *User, actor string) (*User, *util.ResponseError) {
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer tx.Rollback()
+
+	current, responseError := checkVersion(tx, int64(user.Id), user.Version)
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	if responseError := checkEmailAvailable(tx, user.Email, int64(user.Id)); responseError != nil {
+		return nil, responseError
+	}
+
+	updated := *user
+	updated.Email = NormalizeEmail(updated.Email)
+	updated.Version = current.Version + 1
+	updated.DeletedAt = nil
+	updated.Password = ""
+	if updated.Role == "" {
+		updated.Role = current.Role
+	}
+
+	if responseError := saveUser(tx, &updated, newUserChange(UpdatedAction, actor, current, &updated)); responseError != nil {
+		return nil, responseError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, databaseError()
+	}
+	s.index.put(updated)
+
+	return &updated, nil
+}
+
+func (s *userSqlDao) DeleteUser(userId int64, version uint64, actor string) *util.ResponseError {
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return databaseError()
+	}
+	defer tx.Rollback()
+
+	current, responseError := checkVersion(tx, userId, version)
+	if responseError != nil {
+		return responseError
+	}
+
+	deleted := *current
+	deletedAt := now()
+	deleted.DeletedAt = &deletedAt
+	deleted.Version = current.Version + 1
+
+	if responseError := saveUser(tx, &deleted, newUserChange(DeletedAction, actor, current, &deleted)); responseError != nil {
+		return responseError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return databaseError()
+	}
+	s.index.put(deleted)
+
+	return nil
+}
+
+func (s *userSqlDao) RestoreUser(userId int64, actor string) (*User, *util.ResponseError) {
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer tx.Rollback()
+
+	current, responseError := findUser(tx, userId, true)
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	if !current.IsDeleted() {
+		return nil, notDeletedError()
+	}
+
+	restored := *current
+	restored.DeletedAt = nil
+	restored.Version = current.Version + 1
+
+	if responseError := saveUser(tx, &restored, newUserChange(RestoredAction, actor, current, &restored)); responseError != nil {
+		return nil, responseError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, databaseError()
+	}
+	s.index.put(restored)
+
+	return &restored, nil
+}
+
+func (s *userSqlDao) GetUserHistory(userId int64) ([]UserChange, *util.ResponseError) {
+
+	if _, responseError := findUser(s.db, userId, true); responseError != nil {
+		return nil, responseError
+	}
+
+	return s.queryChanges(`SELECT id, user_id, action, actor, created_at, changes FROM user_history
+		WHERE user_id = ? ORDER BY id`, userId)
+}
+
+func (s *userSqlDao) ListChanges(afterId uint64, limit int) ([]UserChange, *util.ResponseError) {
+
+	return s.queryChanges(`SELECT id, user_id, action, actor, created_at, changes FROM user_history
+		WHERE id > ? ORDER BY id LIMIT ?`, afterId, limit)
+}
+
+func (s *userSqlDao) LatestChangeId() (uint64, *util.ResponseError) {
+
+	var latest uint64
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM user_history`).Scan(&latest); err != nil {
+		return 0, databaseError()
+	}
+
+	return latest, nil
+}
+
+func (s *userSqlDao) queryChanges(query string, args ...interface{}) ([]UserChange, *util.ResponseError) {
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer rows.Close()
+
+	history := make([]UserChange, 0)
+	for rows.Next() {
+
+		var change UserChange
+		var changes string
+		if err := rows.Scan(&change.Id, &change.UserId, &change.Action, &change.Actor, &change.Timestamp, &changes); err != nil {
+			return nil, databaseError()
+		}
+
+		if changes != "" {
+			if err := json.Unmarshal([]byte(changes), &change.Changes); err != nil {
+				return nil, databaseError()
+			}
+		}
+		change.Timestamp = change.Timestamp.UTC()
+
+		history = append(history, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, databaseError()
+	}
+
+	return history, nil
+}
+
+// saveUser writes the whole row of an existing user together with the change that produced it.
+func saveUser(tx *sql.Tx, user *User, change *UserChange) *util.ResponseError {
+
+	_, err := tx.Exec(`UPDATE users SET first_name = ?, last_name = ?, email = ?, version = ?, deleted_at = ?,
+		password_hash = ?, role = ? WHERE id = ?`,
+		user.FirstName, user.LastName, user.Email, user.Version, user.DeletedAt, user.PasswordHash, user.Role, user.Id)
+	if err != nil {
+		return databaseError()
+	}
+
+	return recordChange(tx, change)
+}
+
+func recordChange(tx *sql.Tx, change *UserChange) *util.ResponseError {
+
+	var changes string
+	if len(change.Changes) > 0 {
+		data, err := json.Marshal(change.Changes)
+		if err != nil {
+			return databaseError()
+		}
+		changes = string(data)
+	}
+
+	result, err := tx.Exec(`INSERT INTO user_history (user_id, action, actor, created_at, changes) VALUES (?, ?, ?, ?, ?)`,
+		change.UserId, change.Action, change.Actor, change.Timestamp, changes)
+	if err != nil {
+		return databaseError()
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return databaseError()
+	}
+	change.Id = uint64(id)
+
+	return nil
+}
+
+// checkVersion loads the user and verifies it is still at the expected version, zero skips the check.
+func checkVersion(tx *sql.Tx, userId int64, version uint64) (*User, *util.ResponseError) {
+
+	current, responseError := findUser(tx, userId, false)
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	if version != 0 && version != current.Version {
+		return nil, VersionMismatchError()
+	}
+
+	return current, nil
+}
+
+func (s *userSqlDao) ListUsers(query UserQuery) ([]User, string, *util.ResponseError) {
+
+	cursor, responseError := query.cursor()
+	if responseError != nil {
+		return nil, "", responseError
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	if !query.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	filters := []struct {
+		column string
+		value  string
+	}{
+		{"first_name", query.FirstName},
+		{"last_name", query.LastName},
+		{"email", query.Email},
+	}
+	for _, filter := range filters {
+		if filter.value != "" {
+			conditions = append(conditions, fmt.Sprintf("LOWER(%s) = LOWER(?)", filter.column))
+			args = append(args, filter.value)
+		}
+	}
+
+	// the sort field was checked against sortableUserFields, so it is safe to use it as column name
+	column := query.sortField()
+	direction, comparator := "ASC", ">"
+	if query.descending() {
+		direction, comparator = "DESC", "<"
+	}
+
+	if cursor != nil {
+		if column == "id" {
+			conditions = append(conditions, fmt.Sprintf("id %s ?", comparator))
+			args = append(args, cursor.Id)
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, comparator))
+			args = append(args, cursor.Value, cursor.Value, cursor.Id)
+		}
+	}
+
+	statement := `SELECT ` + userColumns + ` FROM users`
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	order := fmt.Sprintf(" ORDER BY id %s", direction)
+	if column != "id" {
+		order = fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+	}
+	statement += order + " LIMIT ?"
+	args = append(args, query.Limit+1)
+
+	rows, err := s.db.Query(statement, args...)
+	if err != nil {
+		return nil, "", databaseError()
+	}
+	defer rows.Close()
+
+	users := make([]User, 0)
+	for rows.Next() {
+		var user User
+		if err := scanUser(rows, &user); err != nil {
+			return nil, "", databaseError()
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", databaseError()
+	}
+
+	results, next := query.page(users)
+	return results, next, nil
+}
+
+func (s *userSqlDao) Close() error {
+
+	return s.db.Close()
+}
+
+func checkEmailAvailable(tx *sql.Tx, email string, userId int64) *util.ResponseError {
+
+	var owner int64
+	err := tx.QueryRow(`SELECT id FROM users WHERE email = ?`, NormalizeEmail(email)).Scan(&owner)
+
+	if err == sql.ErrNoRows {
+		return nil
+	}
+
+	if err != nil {
+		return databaseError()
+	}
+
+	if owner != userId {
+		return emailTakenError(email)
+	}
+
+	return nil
+}
+
+func databaseError() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "database error",
+		Code:    http.StatusInternalServerError,
+	}
+}
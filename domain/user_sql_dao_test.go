@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func newTestSqlDao(t *testing.T) *userSqlDao {
+
+	dao, err := newUserSqlDao(sqliteDriver, ":memory:")
+	assert.Nil(t, err)
+	assert.NotNil(t, dao)
+	return dao
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assert.Nil(t, migrate(dao.db))
+
+	var version int
+	assert.Nil(t, dao.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version))
+	assert.Equal(t, migrations[len(migrations)-1].version, version)
+}
+
+func TestMigrateReportsDuplicateEmails(t *testing.T) {
+
+	db, err := sql.Open(sqliteDriver, ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY)`)
+	assert.Nil(t, err)
+	assert.Nil(t, runMigration(db, migrations[0]))
+	_, err = db.Exec(`INSERT INTO users (id, email) VALUES
+		(1, 'dup@domain.com'), (2, ' DUP@domain.com'), (3, ''), (4, ''), (5, 'unique@domain.com')`)
+	assert.Nil(t, err)
+
+	err = migrate(db)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "dup@domain.com (users 1, 2)")
+	assert.Contains(t, err.Error(), "(empty) (users 3, 4)")
+
+	var version int
+	assert.Nil(t, db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version))
+	assert.Equal(t, 1, version)
+
+	_, err = db.Exec(`UPDATE users SET email = 'user' || id || '@domain.com' WHERE id IN (2, 3, 4)`)
+	assert.Nil(t, err)
+	assert.Nil(t, migrate(db))
+}
+
+func TestSqlDaoCrud(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	created, err := dao.CreateUser(&User{FirstName: "Sql", LastName: "User", Email: "sql@domain.com"}, "tester")
+	assert.Nil(t, err)
+	assert.NotEqual(t, uint64(0), created.Id)
+
+	found, err := dao.GetUser(int64(created.Id))
+	assert.Nil(t, err)
+	assert.Equal(t, "sql@domain.com", found.Email)
+
+	found.FirstName = "Updated"
+	_, err = dao.UpdateUser(found, "tester")
+	assert.Nil(t, err)
+
+	found, err = dao.GetUser(int64(created.Id))
+	assert.Nil(t, err)
+	assert.Equal(t, "Updated", found.FirstName)
+
+	assert.Nil(t, dao.DeleteUser(int64(created.Id), 0, "tester"))
+
+	found, err = dao.GetUser(int64(created.Id))
+	assert.Nil(t, found)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+}
+
+func TestSqlDaoCreateConflict(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	_, err := dao.CreateUser(&User{Id: 7, Email: "first@domain.com"}, "tester")
+	assert.Nil(t, err)
+
+	user, err := dao.CreateUser(&User{Id: 7, Email: "second@domain.com"}, "tester")
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusConflict, err.Code)
+}
+
+func TestSqlDaoUpdateAndDeleteNotFound(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	user, err := dao.UpdateUser(&User{Id: 99, Email: "missing@domain.com"}, "tester")
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusNotFound, err.Code)
+
+	err = dao.DeleteUser(99, 0, "tester")
+	assert.Equal(t, http.StatusNotFound, err.Code)
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestValidateNormalizesEmail(t *testing.T) {
+
+	user := User{FirstName: " First ", Email: "  John.Doe@Domain.COM "}
+	assert.Nil(t, user.Validate())
+	assert.Equal(t, "First", user.FirstName)
+	assert.Equal(t, "john.doe@domain.com", user.Email)
+}
+
+func TestValidateRejectsInvalidEmails(t *testing.T) {
+
+	invalid := []string{"", "plainaddress", "@domain.com", "user@", "user@domain", "John <john@domain.com>",
+		"user@domain..com", "user@.domain.com", "user name@domain.com"}
+
+	for _, email := range invalid {
+		user := User{Email: email}
+		err := user.Validate()
+		assert.NotNil(t, err, email)
+		if err != nil {
+			assert.Equal(t, http.StatusBadRequest, err.Code)
+			assert.Equal(t, "email", err.Fields[0].Field)
+		}
+	}
+}
+
+func assertEmailUniqueness(t *testing.T, dao userDaoInterface) {
+
+	first, err := dao.CreateUser(&User{Email: "unique@domain.com"}, "tester")
+	assert.Nil(t, err)
+
+	user, err := dao.CreateUser(&User{Email: "UNIQUE@domain.com"}, "tester")
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusConflict, err.Code)
+	assert.Equal(t, "email", err.Fields[0].Field)
+
+	second, err := dao.CreateUser(&User{Email: "second@domain.com"}, "tester")
+	assert.Nil(t, err)
+
+	second.Email = "Unique@Domain.com"
+	user, err = dao.UpdateUser(second, "tester")
+	assert.Nil(t, user)
+	assert.Equal(t, http.StatusConflict, err.Code)
+
+	first.Email = "unique@domain.com"
+	first.FirstName = "Same email"
+	_, err = dao.UpdateUser(first, "tester")
+	assert.Nil(t, err)
+
+	// deleted users keep their address so they can be restored
+	assert.Nil(t, dao.DeleteUser(int64(first.Id), 0, "tester"))
+	_, err = dao.CreateUser(&User{Email: "unique@domain.com"}, "tester")
+	assert.Equal(t, http.StatusConflict, err.Code)
+}
+
+func TestEmailUniquenessMemory(t *testing.T) {
+
+	assertEmailUniqueness(t, newUserDaoImpl())
+}
+
+func TestEmailUniquenessSql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertEmailUniqueness(t, dao)
+}
+
+func TestHashPassword(t *testing.T) {
+
+	user := User{Email: "secret@domain.com", Password: "a-long-password"}
+	assert.Nil(t, user.HashPassword())
+	assert.Equal(t, "", user.Password)
+	assert.True(t, user.CheckPassword("a-long-password"))
+	assert.False(t, user.CheckPassword("another-password"))
+	assert.False(t, (&User{}).CheckPassword(""))
+}
+
+func TestValidateRejectsUnknownRole(t *testing.T) {
+
+	user := User{Email: "role@domain.com", Role: "root"}
+	err := user.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "role", err.Fields[0].Field)
+
+	user.Role = RoleOperator
+	assert.Nil(t, user.Validate())
+}
+
+func TestCreateUserDefaultsRole(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	user, err := dao.CreateUser(&User{Email: "viewer@domain.com"}, "tester")
+	assert.Nil(t, err)
+	assert.Equal(t, RoleViewer, user.Role)
+
+	user.Role = RoleAdmin
+	_, err = dao.UpdateUser(user, "tester")
+	assert.Nil(t, err)
+
+	stored, _ := dao.GetUser(int64(user.Id))
+	assert.Equal(t, RoleAdmin, stored.Role)
+}
@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxDeliveriesKept = 100
+
+var (
+	WebhookDao webhookDaoInterface
+)
+
+func init() {
+
+	WebhookDao = newWebhookDaoImpl()
+}
+
+// Webhook is a subscriber to user events. LastEventId is how far it has been served, events
+// are delivered in order and a subscriber only receives the ones after it was registered.
+type Webhook struct {
+	Id          uint64    `json:"id"`
+	Url         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Secret      string    `json:"-"`
+	LastEventId uint64    `json:"last_event_id"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type WebhookRequest struct {
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// CreatedWebhook is the only response carrying the secret payloads are signed with.
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookDelivery is one attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	Id          uint64    `json:"id"`
+	WebhookId   uint64    `json:"webhook_id"`
+	EventId     uint64    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	Attempt     int       `json:"attempt"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Success     bool      `json:"success"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+// Subscribes tells whether the webhook wants events of the given type, no events means all of them.
+func (w *Webhook) Subscribes(eventType string) bool {
+
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *WebhookRequest) Validate() *util.ResponseError {
+
+	r.Url = strings.TrimSpace(r.Url)
+
+	var fields []util.FieldError
+	if parsed, err := url.Parse(r.Url); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		fields = append(fields, util.FieldError{Field: "url", Message: "must be an absolute http or https url"})
+	}
+	for _, event := range r.Events {
+		if !IsUserEventType(event) {
+			fields = append(fields, util.FieldError{Field: "events", Message: "unknown event " + event})
+		}
+	}
+
+	if len(fields) > 0 {
+		return &util.ResponseError{
+			Message: "invalid webhook",
+			Code:    http.StatusBadRequest,
+			Fields:  fields,
+		}
+	}
+
+	return nil
+}
+
+type webhookDaoInterface interface {
+	CreateWebhook(webhook *Webhook) (*Webhook, *util.ResponseError)
+	GetWebhook(webhookId int64) (*Webhook, *util.ResponseError)
+	ListWebhooks() ([]Webhook, *util.ResponseError)
+	DeleteWebhook(webhookId int64) *util.ResponseError
+	AdvanceWebhook(webhookId int64, lastEventId uint64) *util.ResponseError
+	AddDelivery(delivery *WebhookDelivery) (*WebhookDelivery, *util.ResponseError)
+	ListDeliveries(webhookId int64, limit int) ([]WebhookDelivery, *util.ResponseError)
+}
+
+// webhookDaoImpl only keeps the latest deliveries of every webhook.
+type webhookDaoImpl struct {
+	mu             sync.RWMutex
+	webhooks       map[int64]Webhook
+	deliveries     map[int64][]WebhookDelivery
+	lastId         uint64
+	lastDeliveryId uint64
+	// file is where the file store keeps the webhooks, empty in memory
+	file string
+}
+
+func newWebhookDaoImpl() *webhookDaoImpl {
+
+	return &webhookDaoImpl{
+		webhooks:   make(map[int64]Webhook),
+		deliveries: make(map[int64][]WebhookDelivery),
+	}
+}
+
+func (w *webhookDaoImpl) CreateWebhook(webhook *Webhook) (*Webhook, *util.ResponseError) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lastId++
+	created := *webhook
+	created.Id = w.lastId
+	created.CreatedAt = now()
+	w.webhooks[int64(created.Id)] = created
+
+	if err := w.persist(); err != nil {
+		delete(w.webhooks, int64(created.Id))
+		w.lastId--
+		return nil, persistError("webhooks")
+	}
+
+	return &created, nil
+}
+
+func (w *webhookDaoImpl) GetWebhook(webhookId int64) (*Webhook, *util.ResponseError) {
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	webhook, present := w.webhooks[webhookId]
+	if !present {
+		return nil, webhookNotFoundError()
+	}
+
+	return &webhook, nil
+}
+
+func (w *webhookDaoImpl) ListWebhooks() ([]Webhook, *util.ResponseError) {
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	webhooks := make([]Webhook, 0, len(w.webhooks))
+	for _, webhook := range w.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].Id < webhooks[j].Id })
+
+	return webhooks, nil
+}
+
+func (w *webhookDaoImpl) DeleteWebhook(webhookId int64) *util.ResponseError {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	webhook, present := w.webhooks[webhookId]
+	if !present {
+		return webhookNotFoundError()
+	}
+
+	deliveries := w.deliveries[webhookId]
+	delete(w.webhooks, webhookId)
+	delete(w.deliveries, webhookId)
+
+	if err := w.persist(); err != nil {
+		w.webhooks[webhookId] = webhook
+		w.deliveries[webhookId] = deliveries
+		return persistError("webhooks")
+	}
+
+	return nil
+}
+
+func (w *webhookDaoImpl) AdvanceWebhook(webhookId int64, lastEventId uint64) *util.ResponseError {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	webhook, present := w.webhooks[webhookId]
+	if !present {
+		return webhookNotFoundError()
+	}
+
+	previous := webhook
+	webhook.LastEventId = lastEventId
+	w.webhooks[webhookId] = webhook
+
+	if err := w.persist(); err != nil {
+		w.webhooks[webhookId] = previous
+		return persistError("webhooks")
+	}
+
+	return nil
+}
+
+func (w *webhookDaoImpl) AddDelivery(delivery *WebhookDelivery) (*WebhookDelivery, *util.ResponseError) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lastDeliveryId++
+	added := *delivery
+	added.Id = w.lastDeliveryId
+
+	previous := w.deliveries[int64(added.WebhookId)]
+	deliveries := append(previous[:len(previous):len(previous)], added)
+	if len(deliveries) > maxDeliveriesKept {
+		deliveries = deliveries[len(deliveries)-maxDeliveriesKept:]
+	}
+	w.deliveries[int64(added.WebhookId)] = deliveries
+
+	if err := w.persist(); err != nil {
+		w.deliveries[int64(added.WebhookId)] = previous
+		w.lastDeliveryId--
+		return nil, persistError("webhooks")
+	}
+
+	return &added, nil
+}
+
+// ListDeliveries returns the latest deliveries first.
+func (w *webhookDaoImpl) ListDeliveries(webhookId int64, limit int) ([]WebhookDelivery, *util.ResponseError) {
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	kept := w.deliveries[webhookId]
+	deliveries := make([]WebhookDelivery, 0, len(kept))
+	for i := len(kept) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		deliveries = append(deliveries, kept[i])
+	}
+
+	return deliveries, nil
+}
+
+func webhookNotFoundError() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "webhook not found",
+		Code:    http.StatusNotFound,
+	}
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/leandrotula/golangmicroservice/util"
+	"strings"
+)
+
+const (
+	webhookColumns  = "id, url, events, secret, last_event_id, created_by, created_at"
+	deliveryColumns = "id, webhook_id, event_id, event_type, attempt, status_code, error, success, delivered_at"
+)
+
+type webhookSqlDao struct {
+	db *sql.DB
+}
+
+func newWebhookSqlDao(db *sql.DB) *webhookSqlDao {
+
+	return &webhookSqlDao{db: db}
+}
+
+func (s *webhookSqlDao) CreateWebhook(webhook *Webhook) (*Webhook, *util.ResponseError) {
+
+	created := *webhook
+	created.CreatedAt = now()
+
+	result, err := s.db.Exec(`INSERT INTO webhooks (url, events, secret, last_event_id, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		created.Url, strings.Join(created.Events, ","), created.Secret, created.LastEventId, created.CreatedBy, created.CreatedAt)
+	if err != nil {
+		return nil, databaseError()
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, databaseError()
+	}
+	created.Id = uint64(id)
+
+	return &created, nil
+}
+
+func (s *webhookSqlDao) GetWebhook(webhookId int64) (*Webhook, *util.ResponseError) {
+
+	var webhook Webhook
+	if err := scanWebhook(s.db.QueryRow(`SELECT `+webhookColumns+` FROM webhooks WHERE id = ?`, webhookId), &webhook); err != nil {
+
+		if err == sql.ErrNoRows {
+			return nil, webhookNotFoundError()
+		}
+
+		return nil, databaseError()
+	}
+
+	return &webhook, nil
+}
+
+func (s *webhookSqlDao) ListWebhooks() ([]Webhook, *util.ResponseError) {
+
+	rows, err := s.db.Query(`SELECT ` + webhookColumns + ` FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer rows.Close()
+
+	webhooks := make([]Webhook, 0)
+	for rows.Next() {
+		var webhook Webhook
+		if err := scanWebhook(rows, &webhook); err != nil {
+			return nil, databaseError()
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, databaseError()
+	}
+
+	return webhooks, nil
+}
+
+func (s *webhookSqlDao) DeleteWebhook(webhookId int64) *util.ResponseError {
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return databaseError()
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM webhooks WHERE id = ?`, webhookId)
+	if err != nil {
+		return databaseError()
+	}
+
+	if deleted, err := result.RowsAffected(); err != nil || deleted == 0 {
+		return webhookNotFoundError()
+	}
+
+	if _, err := tx.Exec(`DELETE FROM webhook_deliveries WHERE webhook_id = ?`, webhookId); err != nil {
+		return databaseError()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return databaseError()
+	}
+
+	return nil
+}
+
+func (s *webhookSqlDao) AdvanceWebhook(webhookId int64, lastEventId uint64) *util.ResponseError {
+
+	result, err := s.db.Exec(`UPDATE webhooks SET last_event_id = ? WHERE id = ?`, lastEventId, webhookId)
+	if err != nil {
+		return databaseError()
+	}
+
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return webhookNotFoundError()
+	}
+
+	return nil
+}
+
+func (s *webhookSqlDao) AddDelivery(delivery *WebhookDelivery) (*WebhookDelivery, *util.ResponseError) {
+
+	added := *delivery
+
+	result, err := s.db.Exec(`INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, attempt, status_code, error, success, delivered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		added.WebhookId, added.EventId, added.EventType, added.Attempt, added.StatusCode, added.Error, added.Success, added.DeliveredAt)
+	if err != nil {
+		return nil, databaseError()
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, databaseError()
+	}
+	added.Id = uint64(id)
+
+	return &added, nil
+}
+
+func (s *webhookSqlDao) ListDeliveries(webhookId int64, limit int) ([]WebhookDelivery, *util.ResponseError) {
+
+	rows, err := s.db.Query(`SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE webhook_id = ?
+		ORDER BY id DESC LIMIT ?`, webhookId, limit)
+	if err != nil {
+		return nil, databaseError()
+	}
+	defer rows.Close()
+
+	deliveries := make([]WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery WebhookDelivery
+		if err := rows.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Attempt,
+			&delivery.StatusCode, &delivery.Error, &delivery.Success, &delivery.DeliveredAt); err != nil {
+			return nil, databaseError()
+		}
+		delivery.DeliveredAt = delivery.DeliveredAt.UTC()
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, databaseError()
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhook(row interface{ Scan(dest ...interface{}) error }, webhook *Webhook) error {
+
+	var events string
+	if err := row.Scan(&webhook.Id, &webhook.Url, &events, &webhook.Secret, &webhook.LastEventId, &webhook.CreatedBy, &webhook.CreatedAt); err != nil {
+		return err
+	}
+
+	webhook.Events = nil
+	if events != "" {
+		webhook.Events = strings.Split(events, ",")
+	}
+
+	return nil
+}
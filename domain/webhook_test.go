@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func assertWebhooks(t *testing.T, dao webhookDaoInterface) {
+
+	created, err := dao.CreateWebhook(&Webhook{Url: "https://hooks.domain.com/users", Events: []string{UserCreatedEvent},
+		Secret: "secret", LastEventId: 3, CreatedBy: "admin"})
+	assert.Nil(t, err)
+	webhookId := int64(created.Id)
+
+	assert.Nil(t, dao.AdvanceWebhook(webhookId, 7))
+	webhook, err := dao.GetWebhook(webhookId)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 7, webhook.LastEventId)
+	assert.Equal(t, "secret", webhook.Secret)
+	assert.True(t, webhook.Subscribes(UserCreatedEvent))
+	assert.False(t, webhook.Subscribes(UserDeletedEvent))
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		_, err := dao.AddDelivery(&WebhookDelivery{WebhookId: created.Id, EventId: 8, EventType: UserCreatedEvent,
+			Attempt: attempt, StatusCode: http.StatusInternalServerError, DeliveredAt: now()})
+		assert.Nil(t, err)
+	}
+
+	deliveries, err := dao.ListDeliveries(webhookId, 2)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 2)
+	assert.Equal(t, 3, deliveries[0].Attempt)
+
+	webhooks, err := dao.ListWebhooks()
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 1)
+
+	assert.Nil(t, dao.DeleteWebhook(webhookId))
+	assert.Equal(t, http.StatusNotFound, dao.DeleteWebhook(webhookId).Code)
+	assert.Equal(t, http.StatusNotFound, dao.AdvanceWebhook(webhookId, 8).Code)
+}
+
+func TestWebhooksMemory(t *testing.T) {
+
+	assertWebhooks(t, newWebhookDaoImpl())
+}
+
+func TestWebhooksSql(t *testing.T) {
+
+	dao := newTestSqlDao(t)
+	defer dao.Close()
+
+	assertWebhooks(t, newWebhookSqlDao(dao.db))
+}
+
+func TestWebhooksFile(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "webhooks")
+	defer os.RemoveAll(dir)
+
+	dao, openError := newWebhookFileDao(dir)
+	assert.Nil(t, openError)
+	assertWebhooks(t, dao)
+
+	created, err := dao.CreateWebhook(&Webhook{Url: "https://hooks.domain.com/kept", Secret: "kept", CreatedBy: "admin"})
+	assert.Nil(t, err)
+	assert.Nil(t, dao.AdvanceWebhook(int64(created.Id), 11))
+
+	reopened, openError := newWebhookFileDao(dir)
+	assert.Nil(t, openError)
+	webhook, err := reopened.GetWebhook(int64(created.Id))
+	assert.Nil(t, err)
+	assert.Equal(t, "kept", webhook.Secret)
+	assert.EqualValues(t, 11, webhook.LastEventId)
+	assert.Equal(t, dao.lastId, reopened.lastId)
+	assert.Equal(t, dao.lastDeliveryId, reopened.lastDeliveryId)
+
+	os.RemoveAll(dir)
+	assert.EqualValues(t, http.StatusInternalServerError, reopened.AdvanceWebhook(int64(created.Id), 12).Code)
+	webhook, _ = reopened.GetWebhook(int64(created.Id))
+	assert.EqualValues(t, 11, webhook.LastEventId)
+}
+
+func TestWebhookRequestValidate(t *testing.T) {
+
+	request := WebhookRequest{Url: " https://hooks.domain.com ", Events: []string{UserUpdatedEvent}}
+	assert.Nil(t, request.Validate())
+	assert.Equal(t, "https://hooks.domain.com", request.Url)
+
+	request = WebhookRequest{Url: "ftp://hooks.domain.com", Events: []string{"user.renamed"}}
+	err := request.Validate()
+	assert.NotNil(t, err)
+	assert.Len(t, err.Fields, 2)
+}
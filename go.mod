@@ -4,5 +4,11 @@ go 1.14
 
 require (
 	github.com/gin-gonic/gin v1.6.2
-	github.com/stretchr/testify v1.5.1
+	github.com/golang-jwt/jwt/v4 v4.5.0
+	github.com/graphql-go/graphql v0.8.1
+	github.com/mattn/go-sqlite3 v1.14.22
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/crypto v0.1.0
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 )
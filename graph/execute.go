@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxDepth      = 6
+	MaxComplexity = 1000
+
+	// repositoriesEstimate is what a user is assumed to own when estimating the complexity.
+	repositoriesEstimate = 10
+)
+
+type Request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// Execute parses, validates and runs a request. The status is 400 when the request never got to
+// run, errors found while resolving are part of a 200 result as the GraphQL spec expects.
+func Execute(ctx context.Context, request Request) (*graphql.Result, int) {
+
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(request.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, http.StatusBadRequest
+	}
+
+	validation := graphql.ValidateDocument(&schema, document, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}, http.StatusBadRequest
+	}
+
+	if err := checkLimits(document, request.OperationName, request.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, http.StatusBadRequest
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       ctx,
+	})
+
+	return result, http.StatusOK
+}
+
+func resolveError(message string) error {
+
+	return errors.New(message)
+}
+
+// limits walks the selected operation before it runs. Every field costs one, list fields
+// multiply the cost of what is selected below them by the number of items they can return.
+// Introspection is left out, its size is bounded by the schema.
+type limits struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	defaults  map[string]ast.Value
+}
+
+func checkLimits(document *ast.Document, operationName string, variables map[string]interface{}) error {
+
+	walker := limits{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+		defaults:  make(map[string]ast.Value),
+	}
+	var operation *ast.OperationDefinition
+
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			walker.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+
+	if operation == nil {
+		return nil
+	}
+
+	for _, definition := range operation.VariableDefinitions {
+		if definition.DefaultValue != nil {
+			walker.defaults[definition.Variable.Name.Value] = definition.DefaultValue
+		}
+	}
+
+	depth, complexity := walker.selectionSet(operation.SelectionSet)
+	if depth > MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the maximum of %d", depth, MaxDepth)
+	}
+	if complexity > MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d", complexity, MaxComplexity)
+	}
+
+	return nil
+}
+
+func (l *limits) selectionSet(set *ast.SelectionSet) (int, int) {
+
+	if set == nil {
+		return 0, 0
+	}
+
+	depth, complexity := 0, 0
+	for _, selection := range set.Selections {
+
+		var childDepth, childComplexity int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			childDepth, childComplexity = l.selectionSet(selection.SelectionSet)
+			childDepth++
+			childComplexity = 1 + l.multiplier(selection)*childComplexity
+		case *ast.InlineFragment:
+			childDepth, childComplexity = l.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			// validation already rejected unknown and cyclic fragments
+			if fragment, present := l.fragments[selection.Name.Value]; present {
+				childDepth, childComplexity = l.selectionSet(fragment.SelectionSet)
+			}
+		}
+
+		if childDepth > depth {
+			depth = childDepth
+		}
+		complexity += childComplexity
+	}
+
+	return depth, complexity
+}
+
+func (l *limits) multiplier(field *ast.Field) int {
+
+	switch field.Name.Value {
+	case "users":
+		limit := l.intArgument(field, "limit", domain.DefaultListLimit, domain.MaxListLimit)
+		if limit <= 0 || limit > domain.MaxListLimit {
+			return domain.MaxListLimit
+		}
+		return limit
+	case "repositories":
+		return repositoriesEstimate
+	}
+
+	return 1
+}
+
+// intArgument returns the value of the argument, fallback when it is not given and unknown when
+// it is given but its value cannot be told before running the query.
+func (l *limits) intArgument(field *ast.Field, name string, fallback int, unknown int) int {
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value == name {
+			return l.intValue(argument.Value, unknown)
+		}
+	}
+
+	return fallback
+}
+
+func (l *limits) intValue(value ast.Value, unknown int) int {
+
+	switch value := value.(type) {
+	case *ast.IntValue:
+		if parsed, err := strconv.Atoi(value.Value); err == nil {
+			return parsed
+		}
+	case *ast.Variable:
+		name := value.Name.Value
+		switch number := l.variables[name].(type) {
+		case float64:
+			return int(number)
+		case int:
+			return number
+		}
+		// a variable the client left out takes the default of the operation, if it has one
+		if _, sent := l.variables[name]; !sent {
+			if defaultValue, present := l.defaults[name]; present {
+				return l.intValue(defaultValue, unknown)
+			}
+		}
+	}
+
+	return unknown
+}
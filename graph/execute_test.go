@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"context"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestUserWithRepositories(t *testing.T) {
+
+	_, err := domain.RepositoryOwnerDao.SaveRepositoryOwner(&domain.RepositoryOwner{
+		RepositoryId: 77, Name: "graph-repo", FullName: "octocat/graph-repo", UserId: 1})
+	assert.Nil(t, err)
+
+	result, status := Execute(context.Background(), Request{
+		Query:     `query($id: Int!) { user(id: $id) { email repositories { ...repo } } } fragment repo on Repository { name owner { id } }`,
+		Variables: map[string]interface{}{"id": 1},
+	})
+
+	assert.EqualValues(t, http.StatusOK, status)
+	assert.Len(t, result.Errors, 0)
+	user := result.Data.(map[string]interface{})["user"].(map[string]interface{})
+	assert.EqualValues(t, "test@domain.com", user["email"])
+	repositories := user["repositories"].([]interface{})
+	assert.Len(t, repositories, 1)
+	assert.EqualValues(t, "graph-repo", repositories[0].(map[string]interface{})["name"])
+}
+
+func TestUnknownUserIsNull(t *testing.T) {
+
+	result, status := Execute(context.Background(), Request{Query: `{ user(id: 9999) { email } }`})
+
+	assert.EqualValues(t, http.StatusOK, status)
+	assert.Len(t, result.Errors, 0)
+	assert.Nil(t, result.Data.(map[string]interface{})["user"])
+}
+
+func TestQueryDepthLimit(t *testing.T) {
+
+	result, status := Execute(context.Background(), Request{
+		Query: `{ user(id: 1) { repositories { owner { repositories { owner { repositories { owner { id } } } } } } } }`,
+	})
+
+	assert.EqualValues(t, http.StatusBadRequest, status)
+	assert.Contains(t, result.Errors[0].Message, "depth")
+}
+
+func TestQueryComplexityLimit(t *testing.T) {
+
+	result, status := Execute(context.Background(), Request{
+		Query:     `query($limit: Int) { users(limit: $limit) { results { repositories { owner { email repositories { name } } } } } }`,
+		Variables: map[string]interface{}{"limit": float64(100)},
+	})
+
+	assert.EqualValues(t, http.StatusBadRequest, status)
+	assert.Contains(t, result.Errors[0].Message, "complexity")
+
+	_, status = Execute(context.Background(), Request{Query: `{ users(limit: 5) { results { email repositories { name } } } }`})
+	assert.EqualValues(t, http.StatusOK, status)
+}
+
+func TestQueryComplexityLimitUsesVariableDefaults(t *testing.T) {
+
+	query := `query Q($l: Int = 100) { users(limit: $l) { results { id repositories { id } } } }`
+
+	result, status := Execute(context.Background(), Request{Query: query})
+	assert.EqualValues(t, http.StatusBadRequest, status)
+	assert.Contains(t, result.Errors[0].Message, "complexity")
+
+	_, status = Execute(context.Background(), Request{Query: query, Variables: map[string]interface{}{"l": float64(5)}})
+	assert.EqualValues(t, http.StatusOK, status)
+
+	// without a value or a default the limit is unknown, the largest one is assumed
+	result, status = Execute(context.Background(), Request{
+		Query:     `query Q($l: Int) { users(limit: $l) { results { id repositories { id } } } }`,
+		Variables: map[string]interface{}{"l": nil},
+	})
+	assert.EqualValues(t, http.StatusBadRequest, status)
+	assert.Contains(t, result.Errors[0].Message, "complexity")
+}
+
+func TestInvalidQuery(t *testing.T) {
+
+	result, status := Execute(context.Background(), Request{Query: `{ user(id: 1) { password } }`})
+
+	assert.EqualValues(t, http.StatusBadRequest, status)
+	assert.Len(t, result.Errors, 1)
+}
@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/services"
+	"net/http"
+	"time"
+)
+
+// the object types refer to each other, they are built in init to break the initialization cycle
+var (
+	userType       *graphql.Object
+	repositoryType *graphql.Object
+	userPageType   *graphql.Object
+	schema         graphql.Schema
+)
+
+func init() {
+
+	repositoryType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Repository",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: repositoryField(func(r domain.RepositoryOwner) interface{} { return r.RepositoryId })},
+				"name":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: repositoryField(func(r domain.RepositoryOwner) interface{} { return r.Name })},
+				"fullName": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: repositoryField(func(r domain.RepositoryOwner) interface{} { return r.FullName })},
+				"createdAt": &graphql.Field{Type: graphql.String, Resolve: repositoryField(func(r domain.RepositoryOwner) interface{} {
+					if r.CreatedAt.IsZero() {
+						return nil
+					}
+					return r.CreatedAt.Format(time.RFC3339)
+				})},
+				"owner": &graphql.Field{Type: userType, Resolve: resolveRepositoryOwner},
+			}
+		}),
+	})
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: userField(func(u domain.User) interface{} { return u.Id })},
+				"firstName": &graphql.Field{Type: graphql.String, Resolve: userField(func(u domain.User) interface{} { return u.FirstName })},
+				"lastName":  &graphql.Field{Type: graphql.String, Resolve: userField(func(u domain.User) interface{} { return u.LastName })},
+				"email":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: userField(func(u domain.User) interface{} { return u.Email })},
+				"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: userField(func(u domain.User) interface{} { return u.Version })},
+				"role":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: userField(func(u domain.User) interface{} { return u.Role })},
+				"deletedAt": &graphql.Field{Type: graphql.String, Resolve: userField(func(u domain.User) interface{} {
+					if u.DeletedAt == nil {
+						return nil
+					}
+					return u.DeletedAt.Format(time.RFC3339)
+				})},
+				"repositories": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(repositoryType))),
+					Resolve: resolveUserRepositories,
+				},
+			}
+		}),
+	})
+
+	userPageType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserPage",
+		Fields: graphql.Fields{
+			"results": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType)))},
+			"next":    &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type:    userType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.Int)}},
+				Resolve: resolveUser,
+			},
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(userPageType),
+				Args: graphql.FieldConfigArgument{
+					"limit":    {Type: graphql.Int},
+					"after":    {Type: graphql.String},
+					"lastName": {Type: graphql.String},
+					"email":    {Type: graphql.String},
+					"sort":     {Type: graphql.String},
+				},
+				Resolve: resolveUsers,
+			},
+			"repository": &graphql.Field{
+				Type:    repositoryType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.Int)}},
+				Resolve: resolveRepository,
+			},
+		},
+	})
+
+	var err error
+	schema, err = graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func userField(get func(u domain.User) interface{}) graphql.FieldResolveFn {
+
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(domain.User)), nil
+	}
+}
+
+func repositoryField(get func(r domain.RepositoryOwner) interface{}) graphql.FieldResolveFn {
+
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(domain.RepositoryOwner)), nil
+	}
+}
+
+// missing turns a not found into a null, any other error is reported.
+func missing(err error, code int) (interface{}, error) {
+
+	if code == http.StatusNotFound {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func resolveUser(p graphql.ResolveParams) (interface{}, error) {
+
+	user, err := services.GetUser(int64(p.Args["id"].(int)))
+	if err != nil {
+		return missing(resolveError(err.Message), err.Code)
+	}
+
+	return *user, nil
+}
+
+func resolveUsers(p graphql.ResolveParams) (interface{}, error) {
+
+	query := domain.UserQuery{Sort: "id"}
+	if limit, ok := p.Args["limit"].(int); ok {
+		query.Limit = limit
+	}
+	if after, ok := p.Args["after"].(string); ok {
+		query.Cursor = after
+	}
+	if lastName, ok := p.Args["lastName"].(string); ok {
+		query.LastName = lastName
+	}
+	if email, ok := p.Args["email"].(string); ok {
+		query.Email = email
+	}
+	if sort, ok := p.Args["sort"].(string); ok {
+		query.Sort = sort
+	}
+
+	users, next, err := services.ListUsers(query)
+	if err != nil {
+		return nil, resolveError(err.Message)
+	}
+
+	page := map[string]interface{}{"results": users, "next": nil}
+	if next != "" {
+		page["next"] = next
+	}
+
+	return page, nil
+}
+
+func resolveUserRepositories(p graphql.ResolveParams) (interface{}, error) {
+
+	repositories, err := services.GetUserRepositories(int64(p.Source.(domain.User).Id))
+	if err != nil {
+		return nil, resolveError(err.Message)
+	}
+
+	return repositories, nil
+}
+
+func resolveRepository(p graphql.ResolveParams) (interface{}, error) {
+
+	owner, err := services.GetRepository(int64(p.Args["id"].(int)))
+	if err != nil {
+		return missing(resolveError(err.Message), err.Code)
+	}
+
+	return *owner, nil
+}
+
+func resolveRepositoryOwner(p graphql.ResolveParams) (interface{}, error) {
+
+	owner, err := services.GetRepositoryOwner(p.Source.(domain.RepositoryOwner).RepositoryId)
+	if err != nil {
+		return missing(resolveError(err.Message), err.Code)
+	}
+
+	return *owner, nil
+}
@@ -0,0 +1,94 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const (
+	authorizationMetadata = "authorization"
+	apiKeyMetadata        = "x-api-key"
+)
+
+// methodPermissions is the policy of the gRPC api, methods missing from it are denied.
+var methodPermissions = map[string]authorization.Permission{
+	"/golangmicroservice.v1.UserService/GetUser":                      authorization.ReadUsers,
+	"/golangmicroservice.v1.RepositoryService/CreateRepo":             authorization.CreateRepositories,
+	"/golangmicroservice.v1.RepositoryService/CreateRepos":            authorization.CreateRepositories,
+	"/golangmicroservice.v1.RepositoryService/CreateRepoFromTemplate": authorization.CreateRepositories,
+}
+
+type callerKey struct{}
+
+// caller is a user authenticated with an access token, or a service with an api key.
+type caller struct {
+	userId   int64
+	apiKeyId int64
+	role     string
+	scopes   []string
+}
+
+func (c caller) can(permission authorization.Permission) bool {
+
+	if c.apiKeyId != 0 {
+		return authorization.ScopesGrant(c.scopes, permission)
+	}
+
+	return authorization.HasPermission(c.role, permission)
+}
+
+func callerOf(ctx context.Context) caller {
+
+	current, _ := ctx.Value(callerKey{}).(caller)
+	return current
+}
+
+// authenticate accepts the same credentials as the http api: an api key in "x-api-key" or an
+// access token in "authorization".
+func authenticate(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	current, err := identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permission, present := methodPermissions[info.FullMethod]
+	if !present || !current.can(permission) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	return handler(context.WithValue(ctx, callerKey{}, current), request)
+}
+
+func identify(ctx context.Context) (caller, error) {
+
+	incoming, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := incoming.Get(apiKeyMetadata); len(keys) > 0 {
+
+		apiKey, err := services.AuthenticateApiKey(keys[0])
+		if err != nil {
+			return caller{}, statusFromApiError(fromResponseError(err))
+		}
+
+		return caller{apiKeyId: int64(apiKey.Id), scopes: apiKey.Scopes}, nil
+	}
+
+	headers := incoming.Get(authorizationMetadata)
+	if len(headers) == 0 || !strings.HasPrefix(headers[0], "Bearer ") {
+		return caller{}, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	claims, err := services.AuthService.ValidateAccessToken(strings.TrimPrefix(headers[0], "Bearer "))
+	if err != nil {
+		return caller{}, statusFromApiError(fromResponseError(err))
+	}
+
+	return caller{userId: claims.UserId(), role: claims.Role}, nil
+}
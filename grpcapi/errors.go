@@ -0,0 +1,53 @@
+package grpcapi
+
+import (
+	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
+	"github.com/leandrotula/golangmicroservice/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// codeFor translates the http status of an ApiError, so a caller gets the same answer over
+// both apis.
+func codeFor(httpStatus int) codes.Code {
+
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed, http.StatusPreconditionRequired:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+
+	if httpStatus >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+
+	return codes.Unknown
+}
+
+func statusFromApiError(err errorApi.ApiError) error {
+
+	return status.Error(codeFor(err.Status()), err.Message())
+}
+
+func fromResponseError(err *util.ResponseError) errorApi.ApiError {
+
+	return errorApi.NewApiError(err.Message, err.Code)
+}
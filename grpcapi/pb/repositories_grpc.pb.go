@@ -0,0 +1,179 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: repositories.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RepositoryServiceClient is the client API for RepositoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RepositoryServiceClient interface {
+	CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*Repository, error)
+	// CreateRepos reports every repository on its own, like the http endpoint does.
+	CreateRepos(ctx context.Context, in *CreateReposRequest, opts ...grpc.CallOption) (*CreateReposResponse, error)
+	CreateRepoFromTemplate(ctx context.Context, in *CreateRepoFromTemplateRequest, opts ...grpc.CallOption) (*Repository, error)
+}
+
+type repositoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRepositoryServiceClient(cc grpc.ClientConnInterface) RepositoryServiceClient {
+	return &repositoryServiceClient{cc}
+}
+
+func (c *repositoryServiceClient) CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*Repository, error) {
+	out := new(Repository)
+	err := c.cc.Invoke(ctx, "/golangmicroservice.v1.RepositoryService/CreateRepo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoryServiceClient) CreateRepos(ctx context.Context, in *CreateReposRequest, opts ...grpc.CallOption) (*CreateReposResponse, error) {
+	out := new(CreateReposResponse)
+	err := c.cc.Invoke(ctx, "/golangmicroservice.v1.RepositoryService/CreateRepos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoryServiceClient) CreateRepoFromTemplate(ctx context.Context, in *CreateRepoFromTemplateRequest, opts ...grpc.CallOption) (*Repository, error) {
+	out := new(Repository)
+	err := c.cc.Invoke(ctx, "/golangmicroservice.v1.RepositoryService/CreateRepoFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RepositoryServiceServer is the server API for RepositoryService service.
+// All implementations must embed UnimplementedRepositoryServiceServer
+// for forward compatibility
+type RepositoryServiceServer interface {
+	CreateRepo(context.Context, *CreateRepoRequest) (*Repository, error)
+	// CreateRepos reports every repository on its own, like the http endpoint does.
+	CreateRepos(context.Context, *CreateReposRequest) (*CreateReposResponse, error)
+	CreateRepoFromTemplate(context.Context, *CreateRepoFromTemplateRequest) (*Repository, error)
+	mustEmbedUnimplementedRepositoryServiceServer()
+}
+
+// UnimplementedRepositoryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRepositoryServiceServer struct {
+}
+
+func (UnimplementedRepositoryServiceServer) CreateRepo(context.Context, *CreateRepoRequest) (*Repository, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRepo not implemented")
+}
+func (UnimplementedRepositoryServiceServer) CreateRepos(context.Context, *CreateReposRequest) (*CreateReposResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRepos not implemented")
+}
+func (UnimplementedRepositoryServiceServer) CreateRepoFromTemplate(context.Context, *CreateRepoFromTemplateRequest) (*Repository, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRepoFromTemplate not implemented")
+}
+func (UnimplementedRepositoryServiceServer) mustEmbedUnimplementedRepositoryServiceServer() {}
+
+// UnsafeRepositoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RepositoryServiceServer will
+// result in compilation errors.
+type UnsafeRepositoryServiceServer interface {
+	mustEmbedUnimplementedRepositoryServiceServer()
+}
+
+func RegisterRepositoryServiceServer(s grpc.ServiceRegistrar, srv RepositoryServiceServer) {
+	s.RegisterService(&RepositoryService_ServiceDesc, srv)
+}
+
+func _RepositoryService_CreateRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).CreateRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/golangmicroservice.v1.RepositoryService/CreateRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).CreateRepo(ctx, req.(*CreateRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoryService_CreateRepos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReposRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).CreateRepos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/golangmicroservice.v1.RepositoryService/CreateRepos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).CreateRepos(ctx, req.(*CreateReposRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoryService_CreateRepoFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRepoFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).CreateRepoFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/golangmicroservice.v1.RepositoryService/CreateRepoFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).CreateRepoFromTemplate(ctx, req.(*CreateRepoFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RepositoryService_ServiceDesc is the grpc.ServiceDesc for RepositoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RepositoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "golangmicroservice.v1.RepositoryService",
+	HandlerType: (*RepositoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRepo",
+			Handler:    _RepositoryService_CreateRepo_Handler,
+		},
+		{
+			MethodName: "CreateRepos",
+			Handler:    _RepositoryService_CreateRepos_Handler,
+		},
+		{
+			MethodName: "CreateRepoFromTemplate",
+			Handler:    _RepositoryService_CreateRepoFromTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "repositories.proto",
+}
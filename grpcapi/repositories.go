@@ -0,0 +1,126 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/leandrotula/golangmicroservice/grpcapi/pb"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
+	"github.com/leandrotula/golangmicroservice/src/api/repository"
+	"github.com/leandrotula/golangmicroservice/src/api/service"
+)
+
+type repositoryServer struct {
+	pb.UnimplementedRepositoryServiceServer
+}
+
+func (s *repositoryServer) CreateRepo(ctx context.Context, request *pb.CreateRepoRequest) (*pb.Repository, error) {
+
+	apiRequest := toApiRequest(request)
+	created, err := service.CreateRepoOperation.CreateRepo(&apiRequest)
+	if err != nil {
+		return nil, statusFromApiError(err)
+	}
+
+	recordOwner(callerOf(ctx), created)
+
+	return toRepository(created), nil
+}
+
+func (s *repositoryServer) CreateRepos(ctx context.Context, request *pb.CreateReposRequest) (*pb.CreateReposResponse, error) {
+
+	if len(request.GetRepositories()) == 0 {
+		return nil, statusFromApiError(errorApi.NewBadRequestError("no repositories to create"))
+	}
+
+	requests := make([]repository.ApiRequest, 0, len(request.GetRepositories()))
+	for _, single := range request.GetRepositories() {
+		requests = append(requests, toApiRequest(single))
+	}
+
+	created, err := service.CreateRepoOperation.CreateRepos(requests)
+	if err != nil {
+		return nil, statusFromApiError(err)
+	}
+
+	current := callerOf(ctx)
+	response := &pb.CreateReposResponse{}
+	for _, result := range created.Results {
+
+		if result.Response != nil {
+			recordOwner(current, result.Response)
+			response.Results = append(response.Results, &pb.CreateRepoResult{
+				Result: &pb.CreateRepoResult_Repository{Repository: toRepository(result.Response)},
+			})
+			continue
+		}
+
+		response.Results = append(response.Results, &pb.CreateRepoResult{
+			Result: &pb.CreateRepoResult_Error{Error: &pb.Error{
+				Status:  int32(result.Error.Status()),
+				Code:    int32(codeFor(result.Error.Status())),
+				Message: result.Error.Message(),
+			}},
+		})
+	}
+
+	return response, nil
+}
+
+func (s *repositoryServer) CreateRepoFromTemplate(ctx context.Context, request *pb.CreateRepoFromTemplateRequest) (*pb.Repository, error) {
+
+	created, err := service.CreateRepoOperation.CreateRepoFromTemplate(&repository.TemplateRequest{
+		TemplateOwner:      request.GetTemplateOwner(),
+		TemplateRepo:       request.GetTemplateRepo(),
+		Owner:              request.GetOwner(),
+		Name:               request.GetName(),
+		Description:        request.GetDescription(),
+		Private:            request.GetPrivate(),
+		IncludeAllBranches: request.GetIncludeAllBranches(),
+	})
+	if err != nil {
+		return nil, statusFromApiError(err)
+	}
+
+	recordOwner(callerOf(ctx), created)
+
+	return toRepository(created), nil
+}
+
+// toApiRequest keeps unset optional flags as nil, so they get the same defaults as over http.
+func toApiRequest(request *pb.CreateRepoRequest) repository.ApiRequest {
+
+	return repository.ApiRequest{
+		Name:                request.GetName(),
+		Description:         request.GetDescription(),
+		Org:                 request.GetOrg(),
+		Homepage:            request.GetHomepage(),
+		Private:             request.Private,
+		Visibility:          request.GetVisibility(),
+		HasIssues:           request.HasIssues,
+		HasProjects:         request.HasProjects,
+		HasWiki:             request.HasWiki,
+		IsTemplate:          request.GetIsTemplate(),
+		AutoInit:            request.GetAutoInit(),
+		GitignoreTemplate:   request.GetGitignoreTemplate(),
+		LicenseTemplate:     request.GetLicenseTemplate(),
+		AllowSquashMerge:    request.AllowSquashMerge,
+		AllowMergeCommit:    request.AllowMergeCommit,
+		AllowRebaseMerge:    request.AllowRebaseMerge,
+		AllowAutoMerge:      request.GetAllowAutoMerge(),
+		DeleteBranchOnMerge: request.GetDeleteBranchOnMerge(),
+	}
+}
+
+func toRepository(response *repository.ApiResponse) *pb.Repository {
+
+	return &pb.Repository{
+		Id:       int64(response.ID),
+		Name:     response.Name,
+		FullName: response.FullName,
+	}
+}
+
+func recordOwner(current caller, response *repository.ApiResponse) {
+
+	services.RecordRepositoryOwner(int64(response.ID), response.Name, response.FullName, current.userId, current.apiKeyId)
+}
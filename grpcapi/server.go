@@ -0,0 +1,42 @@
+// Package grpcapi serves the user and repository operations over gRPC, for internal callers.
+package grpcapi
+
+//go:generate protoc -I proto --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative users.proto repositories.proto
+
+import (
+	"github.com/leandrotula/golangmicroservice/grpcapi/pb"
+	"google.golang.org/grpc"
+	"net"
+)
+
+// Services selects what the server registers, it follows the apis enabled for http so a disabled
+// module is not reachable over gRPC either.
+type Services struct {
+	Users        bool
+	Repositories bool
+}
+
+func NewServer(services Services) *grpc.Server {
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(authenticate))
+
+	if services.Users {
+		pb.RegisterUserServiceServer(server, &userServer{})
+	}
+
+	if services.Repositories {
+		pb.RegisterRepositoryServiceServer(server, &repositoryServer{})
+	}
+
+	return server
+}
+
+func Serve(address string, services Services) error {
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return NewServer(services).Serve(listener)
+}
@@ -0,0 +1,110 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/grpcapi/pb"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"net/http"
+	"testing"
+)
+
+func dial(t *testing.T) (*grpc.ClientConn, func()) {
+
+	return dialServices(t, Services{Users: true, Repositories: true})
+}
+
+func dialServices(t *testing.T, services Services) (*grpc.ClientConn, func()) {
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(services)
+	go server.Serve(listener)
+
+	connection, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }))
+	assert.Nil(t, err)
+
+	return connection, func() {
+		connection.Close()
+		server.Stop()
+	}
+}
+
+func login(t *testing.T, email string, role string) context.Context {
+
+	_, err := services.CreateUser(domain.User{Email: email, Password: "a-long-password", Role: role}, "tester")
+	assert.Nil(t, err)
+
+	tokens, err := services.AuthService.Login(domain.Credentials{Email: email, Password: "a-long-password"})
+	assert.Nil(t, err)
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+tokens.AccessToken)
+}
+
+func TestDisabledServiceIsNotRegistered(t *testing.T) {
+
+	connection, stop := dialServices(t, Services{Repositories: true})
+	defer stop()
+
+	ctx := login(t, "grpc-disabled@domain.com", domain.RoleAdmin)
+
+	_, err := pb.NewUserServiceClient(connection).GetUser(ctx, &pb.GetUserRequest{Id: 1})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestGetUser(t *testing.T) {
+
+	connection, stop := dial(t)
+	defer stop()
+	client := pb.NewUserServiceClient(connection)
+
+	_, err := client.GetUser(context.Background(), &pb.GetUserRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := login(t, "grpc-viewer@domain.com", domain.RoleViewer)
+
+	user, err := client.GetUser(ctx, &pb.GetUserRequest{Id: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "test@domain.com", user.GetEmail())
+
+	_, err = client.GetUser(ctx, &pb.GetUserRequest{Id: 9999})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCreateRepoRequiresPermission(t *testing.T) {
+
+	connection, stop := dial(t)
+	defer stop()
+
+	ctx := login(t, "grpc-readonly@domain.com", domain.RoleViewer)
+
+	_, err := pb.NewRepositoryServiceClient(connection).CreateRepo(ctx, &pb.CreateRepoRequest{Name: "denied"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCodeFor(t *testing.T) {
+
+	assert.Equal(t, codes.InvalidArgument, codeFor(http.StatusBadRequest))
+	assert.Equal(t, codes.AlreadyExists, codeFor(http.StatusConflict))
+	assert.Equal(t, codes.Internal, codeFor(http.StatusBadGateway))
+	assert.Equal(t, codes.Unknown, codeFor(http.StatusTeapot))
+}
+
+func TestToApiRequestKeepsUnsetFlags(t *testing.T) {
+
+	disabled := false
+	request := toApiRequest(&pb.CreateRepoRequest{Name: "repo", Visibility: "private", HasWiki: &disabled, AutoInit: true})
+
+	assert.EqualValues(t, "private", request.Visibility)
+	assert.False(t, *request.HasWiki)
+	assert.Nil(t, request.HasIssues)
+	assert.Nil(t, request.Private)
+	assert.True(t, request.AutoInit)
+}
@@ -0,0 +1,28 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/leandrotula/golangmicroservice/grpcapi/pb"
+	"github.com/leandrotula/golangmicroservice/services"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+}
+
+func (s *userServer) GetUser(ctx context.Context, request *pb.GetUserRequest) (*pb.User, error) {
+
+	user, err := services.GetUser(request.GetId())
+	if err != nil {
+		return nil, statusFromApiError(fromResponseError(err))
+	}
+
+	return &pb.User{
+		Id:        user.Id,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Version:   user.Version,
+		Role:      user.Role,
+	}, nil
+}
@@ -1,9 +1,39 @@
 package main
 
-import "github.com/leandrotula/golangmicroservice/app"
+import (
+	"flag"
+	"github.com/leandrotula/golangmicroservice/server"
+	"log"
+	"os"
+)
+
+const (
+	grpcAddressKey     = "GRPC_ADDRESS"
+	defaultGrpcAddress = ":9090"
+)
 
 func main() {
 
-	app.StartApp()
+	var config server.Config
+
+	flag.StringVar(&config.Address, "addr", ":8081", "address of the http server")
+	flag.StringVar(&config.GrpcAddress, "grpc-addr", grpcAddress(), "address of the grpc server, empty disables it")
+	flag.BoolVar(&config.Users.Enabled, "users", true, "serve the users api")
+	flag.StringVar(&config.Users.Prefix, "users-prefix", "/api/users", "path prefix of the users api")
+	flag.BoolVar(&config.Repositories.Enabled, "repositories", true, "serve the repositories api")
+	flag.StringVar(&config.Repositories.Prefix, "repositories-prefix", "/api/repositories", "path prefix of the repositories api")
+	flag.Parse()
+
+	if err := server.Run(config); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func grpcAddress() string {
+
+	if address, present := os.LookupEnv(grpcAddressKey); present {
+		return address
+	}
 
+	return defaultGrpcAddress
 }
@@ -0,0 +1,80 @@
+package problem
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+)
+
+const (
+	ContentType = "application/problem+json"
+
+	// DefaultType is the type of a problem that has nothing to add to its status code.
+	DefaultType = "about:blank"
+)
+
+// Problem is an RFC 7807 problem details body, the error body shared by the users and the
+// repositories apis. Errors extends it with the fields that failed validation.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Errors   []util.FieldError `json:"errors,omitempty"`
+}
+
+func (p *Problem) Error() string {
+
+	if p.Detail != "" {
+		return p.Detail
+	}
+
+	return p.Title
+}
+
+func New(status int, detail string) *Problem {
+
+	return &Problem{
+		Type:   DefaultType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func FromResponseError(err *util.ResponseError) *Problem {
+
+	problem := New(err.Code, err.Message)
+	problem.Errors = err.Fields
+
+	return problem
+}
+
+func FromApiError(err errorApi.ApiError) *Problem {
+
+	return New(err.Status(), err.Message())
+}
+
+// Write sends the problem as the response, its instance is the path of the request unless it
+// already names one.
+func Write(c *gin.Context, problem *Problem) {
+
+	if problem.Instance == "" && c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.JSON(problem.Status, problem)
+}
+
+func WriteResponseError(c *gin.Context, err *util.ResponseError) {
+
+	Write(c, FromResponseError(err))
+}
+
+func WriteApiError(c *gin.Context, err errorApi.ApiError) {
+
+	Write(c, FromApiError(err))
+}
@@ -0,0 +1,35 @@
+package problem
+
+import (
+	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
+	"github.com/leandrotula/golangmicroservice/util"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestFromResponseErrorKeepsFields(t *testing.T) {
+
+	problem := FromResponseError(&util.ResponseError{
+		Message: "invalid user",
+		Code:    http.StatusBadRequest,
+		Fields:  []util.FieldError{{Field: "email", Message: "is required"}},
+	})
+
+	assert.EqualValues(t, DefaultType, problem.Type)
+	assert.EqualValues(t, "Bad Request", problem.Title)
+	assert.EqualValues(t, http.StatusBadRequest, problem.Status)
+	assert.EqualValues(t, "invalid user", problem.Detail)
+	assert.Len(t, problem.Errors, 1)
+	assert.EqualValues(t, "email", problem.Errors[0].Field)
+}
+
+func TestFromApiError(t *testing.T) {
+
+	problem := FromApiError(errorApi.NewApiError("Repository creation failed", http.StatusUnprocessableEntity))
+
+	assert.EqualValues(t, "Unprocessable Entity", problem.Title)
+	assert.EqualValues(t, http.StatusUnprocessableEntity, problem.Status)
+	assert.EqualValues(t, "Repository creation failed", problem.Error())
+	assert.Empty(t, problem.Errors)
+}
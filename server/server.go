@@ -0,0 +1,102 @@
+package server
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	userApp "github.com/leandrotula/golangmicroservice/app"
+	"github.com/leandrotula/golangmicroservice/grpcapi"
+	repositoryApp "github.com/leandrotula/golangmicroservice/src/api/app"
+	"github.com/leandrotula/golangmicroservice/src/api/controller"
+	"strings"
+)
+
+// Module is one of the apis the server can host, mounted under its own prefix.
+type Module struct {
+	Enabled bool
+	Prefix  string
+}
+
+type Config struct {
+	Address string
+	// GrpcAddress is where the grpc api listens, empty leaves it off.
+	GrpcAddress  string
+	Users        Module
+	Repositories Module
+}
+
+func (c Config) Validate() error {
+
+	if !c.Users.Enabled && !c.Repositories.Enabled {
+		return errors.New("at least one of the users and repositories apis must be enabled")
+	}
+
+	if c.Users.Enabled && c.Repositories.Enabled && normalize(c.Users.Prefix) == normalize(c.Repositories.Prefix) {
+		return errors.New("the users and repositories apis need distinct prefixes")
+	}
+
+	return nil
+}
+
+// NewRouter mounts the enabled apis, the logging, recovery and health check are shared by both.
+func NewRouter(config Config) (*gin.Engine, error) {
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	router := gin.Default()
+	router.GET("/health", controller.Up)
+
+	if config.Users.Enabled {
+		userApp.MapUrls(router.Group(normalize(config.Users.Prefix)))
+	}
+
+	if config.Repositories.Enabled {
+		repositoryApp.MapUrls(router.Group(normalize(config.Repositories.Prefix)))
+	}
+
+	return router, nil
+}
+
+// Run configures the enabled apis and serves them until the http server stops.
+func Run(config Config) error {
+
+	router, err := NewRouter(config)
+	if err != nil {
+		return err
+	}
+
+	if config.Repositories.Enabled {
+		if err := repositoryApp.Configure(); err != nil {
+			return err
+		}
+	}
+
+	// the user api configures the stores the repository api reads as well
+	if config.Users.Enabled {
+		userApp.Configure()
+	} else {
+		repositoryApp.ConfigureStores()
+	}
+
+	if config.GrpcAddress != "" {
+		services := grpcapi.Services{Users: config.Users.Enabled, Repositories: config.Repositories.Enabled}
+		go func() {
+			if err := grpcapi.Serve(config.GrpcAddress, services); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
+	return router.Run(config.Address)
+}
+
+func normalize(prefix string) string {
+
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return "/"
+	}
+
+	return "/" + prefix
+}
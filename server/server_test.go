@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/problem"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/util"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var bothApis = Config{
+	Users:        Module{Enabled: true, Prefix: "/api/users"},
+	Repositories: Module{Enabled: true, Prefix: "/api/repositories/"},
+}
+
+func serve(router *gin.Engine, method string, path string, body string, token string) *httptest.ResponseRecorder {
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestConfigValidate(t *testing.T) {
+
+	assert.NotNil(t, Config{}.Validate())
+	assert.NotNil(t, Config{
+		Users:        Module{Enabled: true, Prefix: "/api"},
+		Repositories: Module{Enabled: true, Prefix: "api/"},
+	}.Validate())
+	assert.Nil(t, Config{Users: Module{Enabled: true}}.Validate())
+	assert.Nil(t, bothApis.Validate())
+}
+
+func TestNewRouterMountsApisUnderPrefixes(t *testing.T) {
+
+	router, err := NewRouter(bothApis)
+	assert.Nil(t, err)
+
+	assert.EqualValues(t, http.StatusOK, serve(router, http.MethodGet, "/health", "", "").Code)
+	assert.EqualValues(t, http.StatusUnauthorized, serve(router, http.MethodGet, "/api/users/user/1", "", "").Code)
+	assert.EqualValues(t, http.StatusUnauthorized,
+		serve(router, http.MethodPost, "/api/repositories/repository", `{"name":"repo"}`, "").Code)
+	assert.EqualValues(t, http.StatusNotFound, serve(router, http.MethodGet, "/user/1", "", "").Code)
+}
+
+func TestNewRouterAuthorizesPrefixedRoutes(t *testing.T) {
+
+	services.ConfigureAuth("server-test-secret")
+	assert.Nil(t, services.BootstrapUser(domain.User{Email: "server@domain.com", Password: "Secret-password1"}))
+
+	router, err := NewRouter(bothApis)
+	assert.Nil(t, err)
+
+	login := serve(router, http.MethodPost, "/api/users/auth/login",
+		`{"email":"server@domain.com","password":"Secret-password1"}`, "")
+	assert.EqualValues(t, http.StatusOK, login.Code)
+
+	var tokens domain.TokenPair
+	assert.Nil(t, json.Unmarshal(login.Body.Bytes(), &tokens))
+
+	assert.EqualValues(t, http.StatusOK, serve(router, http.MethodGet, "/api/users/user/1", "", tokens.AccessToken).Code)
+	// viewers cannot create repositories, each api answers in its own error format
+	forbidden := serve(router, http.MethodPost, "/api/repositories/repository", `{"name":"repo"}`, tokens.AccessToken)
+	assert.EqualValues(t, http.StatusForbidden, forbidden.Code)
+	assert.Contains(t, forbidden.Header().Get("Content-Type"), problem.ContentType)
+
+	forbidden = serve(router, http.MethodPost, "/api/users/user", `{"email":"new@domain.com"}`, tokens.AccessToken)
+	assert.EqualValues(t, http.StatusForbidden, forbidden.Code)
+	var responseError util.ResponseError
+	assert.Nil(t, json.Unmarshal(forbidden.Body.Bytes(), &responseError))
+	assert.EqualValues(t, http.StatusForbidden, responseError.Code)
+	assert.Equal(t, "forbidden", responseError.Message)
+}
+
+func TestNewRouterSkipsDisabledApis(t *testing.T) {
+
+	router, err := NewRouter(Config{Repositories: Module{Enabled: true}})
+	assert.Nil(t, err)
+
+	assert.EqualValues(t, http.StatusNotFound, serve(router, http.MethodPost, "/api/users/auth/login", "{}", "").Code)
+	assert.EqualValues(t, http.StatusUnauthorized,
+		serve(router, http.MethodPost, "/repository", `{"name":"repo"}`, "").Code)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"time"
+)
+
+const (
+	apiKeyMarker       = "gmk_"
+	apiKeySecretBytes  = 24
+	apiKeyPrefixLength = len(apiKeyMarker) + 8
+)
+
+func CreateApiKey(request domain.ApiKeyRequest, actor string) (*domain.CreatedApiKey, *util.ResponseError) {
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, scope := range request.Scopes {
+		if !authorization.IsPermission(scope) {
+			return nil, &util.ResponseError{
+				Message: "invalid api key",
+				Code:    http.StatusBadRequest,
+				Fields:  []util.FieldError{{Field: "scopes", Message: "unknown scope " + scope}},
+			}
+		}
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, &util.ResponseError{
+			Message: "could not generate api key",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+	key := apiKeyMarker + hex.EncodeToString(secret)
+
+	created, err := domain.ApiKeyDao.CreateApiKey(&domain.ApiKey{
+		Name:      request.Name,
+		Prefix:    key[:apiKeyPrefixLength],
+		Scopes:    request.Scopes,
+		CreatedBy: actor,
+		ExpiresAt: request.ExpiresAt,
+		Hash:      hashApiKey(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatedApiKey{ApiKey: *created, Key: key}, nil
+}
+
+func ListApiKeys() ([]domain.ApiKey, *util.ResponseError) {
+
+	return domain.ApiKeyDao.ListApiKeys()
+}
+
+func RevokeApiKey(keyId int64) (*domain.ApiKey, *util.ResponseError) {
+
+	return domain.ApiKeyDao.RevokeApiKey(keyId)
+}
+
+// AuthenticateApiKey returns the key matching the given one, as long as it is still active.
+func AuthenticateApiKey(key string) (*domain.ApiKey, *util.ResponseError) {
+
+	found, err := domain.ApiKeyDao.GetApiKeyByHash(hashApiKey(key))
+	if err != nil {
+		if err.Code == http.StatusNotFound {
+			return nil, invalidApiKey()
+		}
+		return nil, err
+	}
+
+	if !found.IsActive(time.Now()) {
+		return nil, invalidApiKey()
+	}
+
+	return found, nil
+}
+
+// hashApiKey uses a plain digest, keys are random enough that a slow hash adds nothing and a
+// digest can be looked up directly.
+func hashApiKey(key string) string {
+
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:])
+}
+
+func invalidApiKey() *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: "invalid api key",
+		Code:    http.StatusUnauthorized,
+	}
+}
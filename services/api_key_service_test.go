@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApiKeyLifecycle(t *testing.T) {
+
+	created, err := CreateApiKey(domain.ApiKeyRequest{Name: "ci", Scopes: []string{"repositories:create"}}, "admin")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	assert.NotEqual(t, created.Key, created.Hash)
+
+	key, err := AuthenticateApiKey(created.Key)
+	assert.Nil(t, err)
+	assert.True(t, key.HasScope("repositories:create"))
+
+	_, err = AuthenticateApiKey(created.Key + "x")
+	assert.EqualValues(t, http.StatusUnauthorized, err.Code)
+
+	_, err = RevokeApiKey(int64(created.Id))
+	assert.Nil(t, err)
+
+	_, err = AuthenticateApiKey(created.Key)
+	assert.EqualValues(t, http.StatusUnauthorized, err.Code)
+}
+
+func TestCreateApiKeyValidation(t *testing.T) {
+
+	_, err := CreateApiKey(domain.ApiKeyRequest{Name: "ci", Scopes: []string{"everything"}}, "admin")
+	assert.EqualValues(t, http.StatusBadRequest, err.Code)
+	assert.EqualValues(t, "scopes", err.Fields[0].Field)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = CreateApiKey(domain.ApiKeyRequest{Scopes: []string{"users:read"}, ExpiresAt: &past}, "admin")
+	assert.EqualValues(t, http.StatusBadRequest, err.Code)
+	assert.Len(t, err.Fields, 2)
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+)
+
+type TokenClaims struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	Type  string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// UserId is the id of the authenticated user, taken from the subject of the token.
+func (c *TokenClaims) UserId() int64 {
+
+	id, _ := strconv.ParseInt(c.Subject, 10, 64)
+	return id
+}
+
+type authServiceInterface interface {
+	Login(credentials domain.Credentials) (*domain.TokenPair, *util.ResponseError)
+	Refresh(refreshToken string) (*domain.TokenPair, *util.ResponseError)
+	Revoke(refreshToken string) *util.ResponseError
+	ValidateAccessToken(accessToken string) (*TokenClaims, *util.ResponseError)
+}
+
+// authServiceImpl signs HS256 tokens. Refresh tokens are single use: their ids are kept until
+// they are exchanged, revoked or expired. That list lives in memory, so a restart logs everybody out.
+type authServiceImpl struct {
+	secret        []byte
+	mu            sync.Mutex
+	refreshTokens map[string]time.Time
+}
+
+var (
+	AuthService authServiceInterface
+)
+
+func init() {
+	AuthService = newAuthService(nil)
+}
+
+// ConfigureAuth sets the secret tokens are signed with. Without one a random secret is used,
+// so tokens do not survive a restart.
+func ConfigureAuth(secret string) {
+
+	AuthService = newAuthService([]byte(secret))
+}
+
+func newAuthService(secret []byte) *authServiceImpl {
+
+	if len(secret) == 0 {
+		secret = []byte(randomId() + randomId())
+	}
+
+	return &authServiceImpl{secret: secret, refreshTokens: make(map[string]time.Time)}
+}
+
+func randomId() string {
+
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(buffer)
+}
+
+func unauthorized(message string) *util.ResponseError {
+
+	return &util.ResponseError{
+		Message: message,
+		Code:    http.StatusUnauthorized,
+	}
+}
+
+func (a *authServiceImpl) Login(credentials domain.Credentials) (*domain.TokenPair, *util.ResponseError) {
+
+	if credentials.Email == "" {
+		return nil, unauthorized("invalid credentials")
+	}
+
+	query := domain.UserQuery{Email: domain.NormalizeEmail(credentials.Email), Limit: 1}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	users, _, err := domain.UserDao.ListUsers(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 || !users[0].CheckPassword(credentials.Password) {
+		return nil, unauthorized("invalid credentials")
+	}
+
+	return a.issue(&users[0])
+}
+
+func (a *authServiceImpl) Refresh(refreshToken string) (*domain.TokenPair, *util.ResponseError) {
+
+	claims, err := a.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	_, active := a.refreshTokens[claims.ID]
+	delete(a.refreshTokens, claims.ID)
+	a.mu.Unlock()
+
+	if !active {
+		return nil, unauthorized("refresh token was revoked")
+	}
+
+	user, userError := domain.UserDao.GetUser(claims.UserId())
+	if userError != nil {
+		return nil, unauthorized("user no longer exists")
+	}
+
+	return a.issue(user)
+}
+
+func (a *authServiceImpl) Revoke(refreshToken string) *util.ResponseError {
+
+	claims, err := a.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	delete(a.refreshTokens, claims.ID)
+	a.mu.Unlock()
+
+	return nil
+}
+
+func (a *authServiceImpl) ValidateAccessToken(accessToken string) (*TokenClaims, *util.ResponseError) {
+
+	return a.parse(accessToken, accessTokenType)
+}
+
+func (a *authServiceImpl) issue(user *domain.User) (*domain.TokenPair, *util.ResponseError) {
+
+	issuedAt := time.Now()
+
+	accessToken, err := a.sign(user, accessTokenType, randomId(), issuedAt, accessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshId := randomId()
+	refreshToken, err := a.sign(user, refreshTokenType, refreshId, issuedAt, refreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	for id, expiresAt := range a.refreshTokens {
+		if issuedAt.After(expiresAt) {
+			delete(a.refreshTokens, id)
+		}
+	}
+	a.refreshTokens[refreshId] = issuedAt.Add(refreshTokenTTL)
+	a.mu.Unlock()
+
+	return &domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (a *authServiceImpl) sign(user *domain.User, tokenType string, id string, issuedAt time.Time,
+	ttl time.Duration) (string, *util.ResponseError) {
+
+	claims := TokenClaims{
+		Email: user.Email,
+		Role:  user.Role,
+		Type:  tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Subject:   strconv.FormatUint(user.Id, 10),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(ttl)),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+	if err != nil {
+		return "", &util.ResponseError{
+			Message: "could not sign token",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	return signed, nil
+}
+
+func (a *authServiceImpl) parse(token string, tokenType string) (*TokenClaims, *util.ResponseError) {
+
+	var claims TokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return a.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+	if err != nil || claims.Type != tokenType {
+		return nil, unauthorized("invalid or expired token")
+	}
+
+	return &claims, nil
+}
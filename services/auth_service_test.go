@@ -0,0 +1,104 @@
+package services
+
+import (
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func createLoginUser(t *testing.T) *domain.User {
+
+	user, err := CreateUser(domain.User{Email: "Login@Domain.com", Password: "correct-horse"}, "tester")
+	assert.Nil(t, err)
+	assert.Equal(t, "", user.Password)
+	assert.NotEqual(t, "", user.PasswordHash)
+	return user
+}
+
+func TestLoginIssuesTokens(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	user := createLoginUser(t)
+	auth := newAuthService([]byte("secret"))
+
+	tokens, err := auth.Login(domain.Credentials{Email: "login@domain.com", Password: "correct-horse"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+
+	claims, err := auth.ValidateAccessToken(tokens.AccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(user.Id), claims.UserId())
+	assert.Equal(t, "login@domain.com", claims.Email)
+
+	claims, err = auth.ValidateAccessToken(tokens.RefreshToken)
+	assert.Nil(t, claims)
+	assert.Equal(t, http.StatusUnauthorized, err.Code)
+
+	claims, err = newAuthService([]byte("other secret")).ValidateAccessToken(tokens.AccessToken)
+	assert.Nil(t, claims)
+	assert.Equal(t, http.StatusUnauthorized, err.Code)
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	createLoginUser(t)
+	auth := newAuthService([]byte("secret"))
+
+	tokens, err := auth.Login(domain.Credentials{Email: "login@domain.com", Password: "wrong-password"})
+	assert.Nil(t, tokens)
+	assert.Equal(t, http.StatusUnauthorized, err.Code)
+
+	tokens, err = auth.Login(domain.Credentials{Email: "nobody@domain.com", Password: "correct-horse"})
+	assert.Nil(t, tokens)
+	assert.Equal(t, http.StatusUnauthorized, err.Code)
+}
+
+func TestRefreshTokenIsSingleUse(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	createLoginUser(t)
+	auth := newAuthService([]byte("secret"))
+
+	tokens, _ := auth.Login(domain.Credentials{Email: "login@domain.com", Password: "correct-horse"})
+
+	refreshed, err := auth.Refresh(tokens.RefreshToken)
+	assert.Nil(t, err)
+	assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+	again, err := auth.Refresh(tokens.RefreshToken)
+	assert.Nil(t, again)
+	assert.Equal(t, http.StatusUnauthorized, err.Code)
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	createLoginUser(t)
+	auth := newAuthService([]byte("secret"))
+
+	tokens, _ := auth.Login(domain.Credentials{Email: "login@domain.com", Password: "correct-horse"})
+	assert.Nil(t, auth.Revoke(tokens.RefreshToken))
+
+	refreshed, err := auth.Refresh(tokens.RefreshToken)
+	assert.Nil(t, refreshed)
+	assert.Equal(t, http.StatusUnauthorized, err.Code)
+}
+
+func TestUpdateUserKeepsPasswordUnlessGiven(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	user := createLoginUser(t)
+
+	updated, err := UpdateUser(false, domain.User{Id: user.Id, Email: "login@domain.com"}, "tester")
+	assert.Nil(t, err)
+	assert.True(t, updated.CheckPassword("correct-horse"))
+
+	updated, err = UpdateUser(true, domain.User{Id: user.Id, Password: "battery-staple"}, "tester")
+	assert.Nil(t, err)
+	assert.True(t, updated.CheckPassword("battery-staple"))
+
+	_, err = UpdateUser(true, domain.User{Id: user.Id, Password: "short"}, "tester")
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+}
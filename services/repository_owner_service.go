@@ -0,0 +1,74 @@
+package services
+
+import (
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/util"
+	"log"
+	"net/http"
+)
+
+// RecordRepositoryOwner keeps who created a repository, anonymous callers are not recorded. Every
+// api calls it once the repository exists on GitHub, so a failure is only logged instead of
+// failing the request that created it.
+func RecordRepositoryOwner(repositoryId int64, name string, fullName string, userId int64, apiKeyId int64) {
+
+	if userId == 0 && apiKeyId == 0 {
+		return
+	}
+
+	owner := domain.RepositoryOwner{
+		RepositoryId: repositoryId,
+		Name:         name,
+		FullName:     fullName,
+		UserId:       uint64(userId),
+		ApiKeyId:     uint64(apiKeyId),
+	}
+
+	if _, err := domain.RepositoryOwnerDao.SaveRepositoryOwner(&owner); err != nil {
+		log.Printf("could not record the owner of repository %d: %s", repositoryId, err.Message)
+	}
+}
+
+// GetUserRepositories lists the repositories created by a user, deleted users included since
+// their repositories are still around.
+func GetUserRepositories(userId int64) ([]domain.RepositoryOwner, *util.ResponseError) {
+
+	if _, err := domain.UserDao.GetUserIncludingDeleted(userId); err != nil {
+		return nil, err
+	}
+
+	return domain.RepositoryOwnerDao.ListUserRepositories(userId)
+}
+
+func GetRepositoryOwner(repositoryId int64) (*domain.User, *util.ResponseError) {
+
+	owner, err := domain.RepositoryOwnerDao.GetRepositoryOwner(repositoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner.UserId == 0 {
+		return nil, &util.ResponseError{
+			Message: "repository was created with an api key",
+			Code:    http.StatusNotFound,
+		}
+	}
+
+	return domain.UserDao.GetUserIncludingDeleted(int64(owner.UserId))
+}
+
+func GetRepository(repositoryId int64) (*domain.RepositoryOwner, *util.ResponseError) {
+
+	return domain.RepositoryOwnerDao.GetRepositoryOwner(repositoryId)
+}
+
+func GetRepositoryByFullName(fullName string) (*domain.RepositoryOwner, *util.ResponseError) {
+
+	return domain.RepositoryOwnerDao.GetRepositoryOwnerByFullName(fullName)
+}
+
+// ForgetRepository drops the owner of a repository that no longer exists on GitHub.
+func ForgetRepository(repositoryId int64) *util.ResponseError {
+
+	return domain.RepositoryOwnerDao.DeleteRepositoryOwner(repositoryId)
+}
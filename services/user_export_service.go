@@ -0,0 +1,211 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/util"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	CsvExport    = "csv"
+	NdjsonExport = "ndjson"
+	JsonExport   = "json"
+)
+
+var exportFields = map[string]func(user *domain.User) interface{}{
+	"id":         func(user *domain.User) interface{} { return user.Id },
+	"first_name": func(user *domain.User) interface{} { return user.FirstName },
+	"last_name":  func(user *domain.User) interface{} { return user.LastName },
+	"email":      func(user *domain.User) interface{} { return user.Email },
+	"role":       func(user *domain.User) interface{} { return user.Role },
+	"version":    func(user *domain.User) interface{} { return user.Version },
+	"deleted_at": func(user *domain.User) interface{} {
+		if user.DeletedAt == nil {
+			return nil
+		}
+		return user.DeletedAt.UTC().Format(time.RFC3339)
+	},
+}
+
+var defaultExportFields = []string{"id", "first_name", "last_name", "email", "role", "version"}
+
+var exportContentTypes = map[string]string{
+	CsvExport:    "text/csv",
+	NdjsonExport: "application/x-ndjson",
+	JsonExport:   "application/json",
+}
+
+// UserExport streams every user, a page at a time, so memory does not grow with the number of users.
+type UserExport struct {
+	format         string
+	fields         []string
+	includeDeleted bool
+}
+
+// NewUserExport validates the export options up front, once the export starts writing the
+// response status can no longer change. fields is a comma separated list, empty means the default
+// ones, which include deleted_at when the deleted users are exported too.
+func NewUserExport(format string, fields string, includeDeleted bool) (*UserExport, *util.ResponseError) {
+
+	if format == "" {
+		format = JsonExport
+	}
+
+	if _, present := exportContentTypes[format]; !present {
+		return nil, &util.ResponseError{
+			Message: "invalid export format, use csv, ndjson or json",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	export := &UserExport{format: format, fields: defaultExportFields, includeDeleted: includeDeleted}
+	if includeDeleted {
+		export.fields = append(append([]string(nil), defaultExportFields...), "deleted_at")
+	}
+
+	if fields != "" {
+		export.fields = nil
+		for _, field := range strings.Split(fields, ",") {
+
+			field = strings.TrimSpace(field)
+			if exportFields[field] == nil {
+				return nil, &util.ResponseError{
+					Message: fmt.Sprintf("invalid export field %q", field),
+					Code:    http.StatusBadRequest,
+				}
+			}
+			export.fields = append(export.fields, field)
+		}
+	}
+
+	return export, nil
+}
+
+func (e *UserExport) ContentType() string {
+
+	return exportContentTypes[e.format]
+}
+
+// WriteTo writes all the users to w, calling flush after every page.
+func (e *UserExport) WriteTo(w io.Writer, flush func()) error {
+
+	var csvWriter *csv.Writer
+
+	switch e.format {
+	case CsvExport:
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(e.fields); err != nil {
+			return err
+		}
+	case JsonExport:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+	}
+
+	query := domain.UserQuery{IncludeDeleted: e.includeDeleted, Limit: domain.MaxListLimit}
+	written := 0
+
+	for {
+
+		if err := query.Validate(); err != nil {
+			return errors.New(err.Message)
+		}
+
+		users, next, err := domain.UserDao.ListUsers(query)
+		if err != nil {
+			return errors.New(err.Message)
+		}
+
+		for i := range users {
+
+			var writeError error
+			switch e.format {
+			case CsvExport:
+				writeError = csvWriter.Write(e.csvRecord(&users[i]))
+			case NdjsonExport:
+				writeError = e.writeObject(w, &users[i], "", "\n")
+			case JsonExport:
+				separator := ","
+				if written == 0 {
+					separator = ""
+				}
+				writeError = e.writeObject(w, &users[i], separator, "")
+			}
+
+			if writeError != nil {
+				return writeError
+			}
+			written++
+		}
+
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		flush()
+
+		if next == "" {
+			break
+		}
+		query.Cursor = next
+	}
+
+	if e.format == JsonExport {
+		if _, err := io.WriteString(w, "]"); err != nil {
+			return err
+		}
+		flush()
+	}
+
+	return nil
+}
+
+func (e *UserExport) csvRecord(user *domain.User) []string {
+
+	record := make([]string, len(e.fields))
+	for i, field := range e.fields {
+		// an empty cell for values json writes as null
+		if value := exportFields[field](user); value != nil {
+			record[i] = fmt.Sprint(value)
+		}
+	}
+
+	return record
+}
+
+// writeObject encodes the selected fields in their requested order, which a map would not keep.
+func (e *UserExport) writeObject(w io.Writer, user *domain.User, prefix string, suffix string) error {
+
+	var buffer bytes.Buffer
+	buffer.WriteString(prefix + "{")
+
+	for i, field := range e.fields {
+
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		value, err := json.Marshal(exportFields[field](user))
+		if err != nil {
+			return err
+		}
+
+		buffer.WriteString(`"` + field + `":`)
+		buffer.Write(value)
+	}
+
+	buffer.WriteString("}" + suffix)
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func seedExportUsers(t *testing.T, count int) {
+
+	for i := 1; i <= count; i++ {
+		_, err := CreateUser(domain.User{FirstName: fmt.Sprintf("Name%d", i), Email: fmt.Sprintf("user%d@domain.com", i)}, "tester")
+		assert.Nil(t, err)
+	}
+}
+
+func TestExportUsersCsvAcrossPages(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	seedExportUsers(t, domain.MaxListLimit+5)
+
+	export, err := NewUserExport(CsvExport, "id,email", false)
+	assert.Nil(t, err)
+
+	var output bytes.Buffer
+	flushes := 0
+	assert.Nil(t, export.WriteTo(&output, func() { flushes++ }))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, domain.MaxListLimit+6)
+	assert.Equal(t, "id,email", lines[0])
+	assert.Equal(t, "1,user1@domain.com", lines[1])
+	assert.Equal(t, 2, flushes)
+}
+
+func TestExportUsersJson(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	seedExportUsers(t, 3)
+
+	export, err := NewUserExport(JsonExport, "email,first_name", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "application/json", export.ContentType())
+
+	var output bytes.Buffer
+	assert.Nil(t, export.WriteTo(&output, func() {}))
+	assert.True(t, strings.HasPrefix(output.String(), `[{"email":"user1@domain.com","first_name":"Name1"},`))
+
+	var users []domain.User
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &users))
+	assert.Len(t, users, 3)
+}
+
+func TestExportUsersNdjsonSkipsDeleted(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	seedExportUsers(t, 2)
+	assert.Nil(t, DeleteUser(1, 0, "tester"))
+
+	export, _ := NewUserExport(NdjsonExport, "", false)
+	var output bytes.Buffer
+	assert.Nil(t, export.WriteTo(&output, func() {}))
+	assert.Equal(t, `{"id":2,"first_name":"Name2","last_name":"","email":"user2@domain.com","role":"viewer","version":1}`+"\n", output.String())
+
+	export, _ = NewUserExport(NdjsonExport, "id", true)
+	output.Reset()
+	assert.Nil(t, export.WriteTo(&output, func() {}))
+	assert.Equal(t, "{\"id\":1}\n{\"id\":2}\n", output.String())
+}
+
+func TestExportUsersIncludingDeletedMarksThem(t *testing.T) {
+
+	defer useMemoryDao(t)()
+	seedExportUsers(t, 2)
+	assert.Nil(t, DeleteUser(1, 0, "tester"))
+
+	export, _ := NewUserExport(CsvExport, "", true)
+	var output bytes.Buffer
+	assert.Nil(t, export.WriteTo(&output, func() {}))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "id,first_name,last_name,email,role,version,deleted_at", lines[0])
+	assert.Regexp(t, `^1,Name1,,user1@domain.com,viewer,\d+,\d{4}-\d{2}-\d{2}T`, lines[1])
+	assert.Equal(t, "2,Name2,,user2@domain.com,viewer,1,", lines[2])
+	assert.Equal(t, []string{"id", "first_name", "last_name", "email", "role", "version"}, defaultExportFields)
+}
+
+func TestExportUsersInvalidOptions(t *testing.T) {
+
+	export, err := NewUserExport("xml", "", false)
+	assert.Nil(t, export)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+
+	export, err = NewUserExport(CsvExport, "email,password", false)
+	assert.Nil(t, export)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/util"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	CsvFormat    = "text/csv"
+	NdjsonFormat = "application/x-ndjson"
+
+	maxNdjsonLine = 1024 * 1024
+)
+
+// userReader returns the users of an import one at a time. A record that cannot be decoded is
+// reported through recordError, while err means the rest of the input cannot be read.
+type userReader interface {
+	next() (user domain.User, recordError string, err error)
+}
+
+// ImportUsers reads the users from body and creates each valid one, reporting the outcome of
+// every record. With dryRun nothing is created, duplicates are detected against the stored users.
+func ImportUsers(format string, body io.Reader, dryRun bool, actor string) (*domain.ImportReport, *util.ResponseError) {
+
+	reader, err := newUserReader(format, body)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{DryRun: dryRun, Rows: make([]domain.ImportRow, 0)}
+	seen := make(map[string]bool)
+
+	for record := 1; ; record++ {
+
+		user, recordError, readError := reader.next()
+		if readError == io.EOF {
+			return report, nil
+		}
+
+		if readError != nil {
+			return nil, &util.ResponseError{
+				Message: fmt.Sprintf("could not read record %d: %v", record, readError),
+				Code:    http.StatusBadRequest,
+			}
+		}
+
+		report.Add(importUser(record, user, recordError, dryRun, seen, actor))
+	}
+}
+
+func importUser(record int, user domain.User, recordError string, dryRun bool, seen map[string]bool, actor string) domain.ImportRow {
+
+	result := domain.ImportRow{Record: record, Email: user.Email}
+
+	if recordError != "" {
+		result.Status = domain.ImportInvalid
+		result.Reason = recordError
+		return result
+	}
+
+	if err := user.Validate(); err != nil {
+		result.Status = domain.ImportInvalid
+		result.Reason = describe(err)
+		return result
+	}
+	result.Email = user.Email
+	// roles are granted one user at a time, imported users always start with the default one
+	user.Role = domain.DefaultRole
+
+	if seen[user.Email] {
+		result.Status = domain.ImportDuplicate
+		result.Reason = "email appears earlier in the import"
+		return result
+	}
+	seen[user.Email] = true
+
+	// duplicates are ruled out before paying for bcrypt, only the users being created are hashed
+	if result = checkExisting(result, user); result.Status != "" {
+		return result
+	}
+
+	if dryRun {
+		result.Status = domain.ImportCreated
+		return result
+	}
+
+	if err := user.HashPassword(); err != nil {
+		result.Status = domain.ImportInvalid
+		result.Reason = describe(err)
+		return result
+	}
+
+	created, err := domain.UserDao.CreateUser(&user, actor)
+	if err != nil {
+		result.Status = domain.ImportInvalid
+		if err.Code == http.StatusConflict {
+			result.Status = domain.ImportDuplicate
+		}
+		result.Reason = describe(err)
+		return result
+	}
+
+	result.Status = domain.ImportCreated
+	result.Id = created.Id
+	return result
+}
+
+// checkExisting sets the status of users the store already has, leaving it empty for new ones.
+func checkExisting(result domain.ImportRow, user domain.User) domain.ImportRow {
+
+	query := domain.UserQuery{Email: user.Email, IncludeDeleted: true, Limit: 1}
+	if err := query.Validate(); err != nil {
+		result.Status = domain.ImportInvalid
+		result.Reason = describe(err)
+		return result
+	}
+
+	existing, _, err := domain.UserDao.ListUsers(query)
+	if err != nil {
+		result.Status = domain.ImportInvalid
+		result.Reason = describe(err)
+		return result
+	}
+
+	if len(existing) > 0 {
+		result.Status = domain.ImportDuplicate
+		result.Reason = "email already in use"
+		return result
+	}
+
+	if user.Id != 0 {
+		if _, err := domain.UserDao.GetUserIncludingDeleted(int64(user.Id)); err == nil {
+			result.Status = domain.ImportDuplicate
+			result.Reason = "User already exists"
+			return result
+		}
+	}
+
+	return result
+}
+
+func describe(err *util.ResponseError) string {
+
+	if len(err.Fields) == 0 {
+		return err.Message
+	}
+
+	reasons := make([]string, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		reasons = append(reasons, field.Field+": "+field.Message)
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+func newUserReader(format string, body io.Reader) (userReader, *util.ResponseError) {
+
+	switch format {
+	case CsvFormat:
+		return newCsvUserReader(body)
+	case NdjsonFormat:
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxNdjsonLine)
+		return &ndjsonUserReader{scanner: scanner}, nil
+	}
+
+	return nil, &util.ResponseError{
+		Message: "unsupported import format, use " + CsvFormat + " or " + NdjsonFormat,
+		Code:    http.StatusUnsupportedMediaType,
+	}
+}
+
+type csvUserReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCsvUserReader(body io.Reader) (*csvUserReader, *util.ResponseError) {
+
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, &util.ResponseError{
+			Message: "csv header is missing",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, present := columns["email"]; !present {
+		return nil, &util.ResponseError{
+			Message: "csv header must have an email column",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return &csvUserReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvUserReader) next() (domain.User, string, error) {
+
+	record, err := r.reader.Read()
+	if err != nil {
+		if parseError, ok := err.(*csv.ParseError); ok && parseError.Err == csv.ErrFieldCount {
+			return domain.User{}, "wrong number of columns", nil
+		}
+		return domain.User{}, "", err
+	}
+
+	value := func(column string) string {
+		if i, present := r.columns[column]; present {
+			return record[i]
+		}
+		return ""
+	}
+
+	user := domain.User{
+		FirstName: value("first_name"),
+		LastName:  value("last_name"),
+		Email:     value("email"),
+	}
+
+	if id := strings.TrimSpace(value("id")); id != "" {
+		parsedId, parserError := strconv.ParseUint(id, 10, 64)
+		if parserError != nil {
+			return user, "invalid id", nil
+		}
+		user.Id = parsedId
+	}
+
+	return user, "", nil
+}
+
+type ndjsonUserReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *ndjsonUserReader) next() (domain.User, string, error) {
+
+	for r.scanner.Scan() {
+
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var user domain.User
+		if err := json.Unmarshal([]byte(line), &user); err != nil {
+			return domain.User{}, "invalid json", nil
+		}
+
+		return user, "", nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return domain.User{}, "", err
+	}
+
+	return domain.User{}, "", io.EOF
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// useMemoryDao swaps the mock for an empty in-memory dao until the returned function is called.
+func useMemoryDao(t *testing.T) func() {
+
+	previous := domain.UserDao
+	assert.Nil(t, domain.ConfigureUserDao(domain.MemoryStore, ""))
+
+	return func() {
+		domain.UserDao = previous
+	}
+}
+
+func TestImportUsersCsv(t *testing.T) {
+
+	defer useMemoryDao(t)()
+
+	body := "email,first_name,last_name\n" +
+		"ann@domain.com,Ann,Lee\n" +
+		"not-an-email,Bad,Row\n" +
+		"ANN@domain.com,Ann,Again\n" +
+		"too,many,columns,here\n" +
+		"joe@domain.com,Joe,Doe\n"
+
+	report, err := ImportUsers(CsvFormat, strings.NewReader(body), false, "importer")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Duplicates)
+	assert.Equal(t, 2, report.Invalid)
+
+	statuses := []string{domain.ImportCreated, domain.ImportInvalid, domain.ImportDuplicate, domain.ImportInvalid, domain.ImportCreated}
+	for i, status := range statuses {
+		assert.Equal(t, i+1, report.Rows[i].Record)
+		assert.Equal(t, status, report.Rows[i].Status)
+	}
+	assert.Contains(t, report.Rows[1].Reason, "email")
+
+	user, userErr := GetUser(int64(report.Rows[4].Id))
+	assert.Nil(t, userErr)
+	assert.Equal(t, "Joe", user.FirstName)
+}
+
+func TestImportUsersNdjsonSkipsExisting(t *testing.T) {
+
+	defer useMemoryDao(t)()
+
+	_, err := CreateUser(domain.User{Email: "existing@domain.com"}, "tester")
+	assert.Nil(t, err)
+
+	body := `{"first_name":"New","email":"new@domain.com"}` + "\n\n" +
+		`{"email":"existing@domain.com"}` + "\n" +
+		`{"email":` + "\n"
+
+	report, err := ImportUsers(NdjsonFormat, strings.NewReader(body), false, "importer")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Duplicates)
+	assert.Equal(t, 1, report.Invalid)
+	assert.Equal(t, "invalid json", report.Rows[2].Reason)
+}
+
+func TestImportUsersDryRun(t *testing.T) {
+
+	defer useMemoryDao(t)()
+
+	_, err := CreateUser(domain.User{Email: "existing@domain.com"}, "tester")
+	assert.Nil(t, err)
+
+	body := "email\nexisting@domain.com\nfresh@domain.com\n"
+
+	report, err := ImportUsers(CsvFormat, strings.NewReader(body), true, "importer")
+	assert.Nil(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Duplicates)
+
+	users, _, err := ListUsers(domain.UserQuery{Email: "fresh@domain.com"})
+	assert.Nil(t, err)
+	assert.Len(t, users, 0)
+}
+
+func TestImportUsersUnsupportedFormat(t *testing.T) {
+
+	report, err := ImportUsers("application/json", strings.NewReader("[]"), false, "importer")
+	assert.Nil(t, report)
+	assert.Equal(t, http.StatusUnsupportedMediaType, err.Code)
+}
+
+func TestImportUsersCsvWithoutEmailColumn(t *testing.T) {
+
+	report, err := ImportUsers(CsvFormat, strings.NewReader("first_name\nAnn\n"), false, "importer")
+	assert.Nil(t, report)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+}
@@ -3,9 +3,151 @@ package services
 import (
 	"github.com/leandrotula/golangmicroservice/domain"
 	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+	"strings"
 )
 
 func GetUser(id int64) (*domain.User, *util.ResponseError) {
 
 	return domain.UserDao.GetUser(id)
 }
+
+func GetUserIncludingDeleted(id int64) (*domain.User, *util.ResponseError) {
+
+	return domain.UserDao.GetUserIncludingDeleted(id)
+}
+
+func CreateUser(user domain.User, actor string) (*domain.User, *util.ResponseError) {
+
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := user.HashPassword(); err != nil {
+		return nil, err
+	}
+
+	return domain.UserDao.CreateUser(&user, actor)
+}
+
+func UpdateUser(isPartial bool, user domain.User, actor string) (*domain.User, *util.ResponseError) {
+
+	current, err := domain.UserDao.GetUser(int64(user.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	// user.Version is the version the caller based its changes on, zero means any
+	expectedVersion := user.Version
+	if expectedVersion == 0 {
+		expectedVersion = current.Version
+	}
+
+	if expectedVersion != current.Version {
+		return nil, domain.VersionMismatchError()
+	}
+
+	if isPartial {
+		if user.FirstName != "" {
+			current.FirstName = user.FirstName
+		}
+		if user.LastName != "" {
+			current.LastName = user.LastName
+		}
+		if user.Email != "" {
+			current.Email = user.Email
+		}
+	} else {
+		current.FirstName = user.FirstName
+		current.LastName = user.LastName
+		current.Email = user.Email
+	}
+
+	// a full update replaces the profile, the password and role only change when given
+	current.Password = user.Password
+	if user.Role != "" {
+		current.Role = user.Role
+	}
+	current.Version = expectedVersion
+
+	if err := current.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := current.HashPassword(); err != nil {
+		return nil, err
+	}
+
+	return domain.UserDao.UpdateUser(current, actor)
+}
+
+func DeleteUser(id int64, version uint64, actor string) *util.ResponseError {
+
+	return domain.UserDao.DeleteUser(id, version, actor)
+}
+
+func RestoreUser(id int64, actor string) (*domain.User, *util.ResponseError) {
+
+	return domain.UserDao.RestoreUser(id, actor)
+}
+
+func GetUserHistory(id int64) ([]domain.UserChange, *util.ResponseError) {
+
+	return domain.UserDao.GetUserHistory(id)
+}
+
+func ListUsers(query domain.UserQuery) ([]domain.User, string, *util.ResponseError) {
+
+	if err := query.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	return domain.UserDao.ListUsers(query)
+}
+
+func SearchUsers(query string, limit int) ([]domain.UserMatch, *util.ResponseError) {
+
+	if strings.TrimSpace(query) == "" {
+		return nil, &util.ResponseError{
+			Message: "search query is required",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if limit <= 0 {
+		limit = domain.DefaultSearchLimit
+	}
+
+	if limit > domain.MaxListLimit {
+		limit = domain.MaxListLimit
+	}
+
+	return domain.UserDao.SearchUsers(query, limit)
+}
+
+// BootstrapUser creates the given user unless one with the same email exists, so a fresh
+// deployment has somebody able to log in.
+func BootstrapUser(user domain.User) *util.ResponseError {
+
+	query := domain.UserQuery{Email: domain.NormalizeEmail(user.Email), IncludeDeleted: true, Limit: 1}
+	if err := query.Validate(); err != nil {
+		return err
+	}
+
+	existing, _, err := domain.UserDao.ListUsers(query)
+	if err != nil {
+		return err
+	}
+
+	if len(existing) > 0 {
+		return nil
+	}
+
+	_, err = CreateUser(user, "bootstrap")
+	return err
+}
+
+func GetUserCacheStats() (*domain.CacheStats, *util.ResponseError) {
+
+	return domain.UserCacheStats()
+}
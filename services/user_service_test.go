@@ -7,7 +7,10 @@ import (
 	"testing"
 )
 
-var generateMockData func (id int64) (*domain.User, *util.ResponseError)
+var (
+	generateMockData func (id int64) (*domain.User, *util.ResponseError)
+	updateMockData   func (user *domain.User) (*domain.User, *util.ResponseError)
+)
 
 type mockDaoImpl struct {
 }
@@ -20,6 +23,47 @@ func(m *mockDaoImpl) GetUser(userId int64)(*domain.User, *util.ResponseError) {
 	return generateMockData(userId)
 }
 
+func(m *mockDaoImpl) GetUserIncludingDeleted(userId int64)(*domain.User, *util.ResponseError) {
+	return generateMockData(userId)
+}
+
+func(m *mockDaoImpl) CreateUser(user *domain.User, actor string)(*domain.User, *util.ResponseError) {
+	return user, nil
+}
+
+func(m *mockDaoImpl) UpdateUser(user *domain.User, actor string)(*domain.User, *util.ResponseError) {
+	return updateMockData(user)
+}
+
+func(m *mockDaoImpl) ListUsers(query domain.UserQuery)([]domain.User, string, *util.ResponseError) {
+	return []domain.User{}, "", nil
+}
+
+func(m *mockDaoImpl) DeleteUser(userId int64, version uint64, actor string) *util.ResponseError {
+	_, err := generateMockData(userId)
+	return err
+}
+
+func(m *mockDaoImpl) RestoreUser(userId int64, actor string)(*domain.User, *util.ResponseError) {
+	return generateMockData(userId)
+}
+
+func(m *mockDaoImpl) SearchUsers(query string, limit int)([]domain.UserMatch, *util.ResponseError) {
+	return []domain.UserMatch{}, nil
+}
+
+func(m *mockDaoImpl) GetUserHistory(userId int64)([]domain.UserChange, *util.ResponseError) {
+	return []domain.UserChange{}, nil
+}
+
+func(m *mockDaoImpl) ListChanges(afterId uint64, limit int)([]domain.UserChange, *util.ResponseError) {
+	return []domain.UserChange{}, nil
+}
+
+func(m *mockDaoImpl) LatestChangeId()(uint64, *util.ResponseError) {
+	return 0, nil
+}
+
 func TestGetUserNotFound(t *testing.T) {
 
 	generateMockData = func(id int64) (*domain.User, *util.ResponseError) {
@@ -58,3 +102,109 @@ func TestGetUserFound(t *testing.T) {
 
 
 }
+
+func TestCreateUserInvalidEmail(t *testing.T) {
+
+	user, err := CreateUser(domain.User{FirstName: "User3", Email: "  "}, "tester")
+	assert.Nil(t, user)
+	assert.NotNil(t, err)
+	assert.Equal(t, 400, err.Code)
+}
+
+func TestPartialUpdateKeepsMissingFields(t *testing.T) {
+
+	generateMockData = func(id int64) (*domain.User, *util.ResponseError) {
+		return &domain.User{
+			Id:        uint64(id),
+			FirstName: "User2",
+			LastName:  "LastNameUser2",
+			Email:     "test2@domain.com",
+		}, nil
+	}
+	updateMockData = func(user *domain.User) (*domain.User, *util.ResponseError) {
+		return user, nil
+	}
+
+	user, err := UpdateUser(true, domain.User{Id: 2, FirstName: "Renamed"}, "tester")
+	assert.Nil(t, err)
+	assert.NotNil(t, user)
+	assert.Equal(t, "Renamed", user.FirstName)
+	assert.Equal(t, "LastNameUser2", user.LastName)
+	assert.Equal(t, "test2@domain.com", user.Email)
+}
+
+func TestFullUpdateReplacesFields(t *testing.T) {
+
+	generateMockData = func(id int64) (*domain.User, *util.ResponseError) {
+		return &domain.User{
+			Id:        uint64(id),
+			FirstName: "User2",
+			LastName:  "LastNameUser2",
+			Email:     "test2@domain.com",
+		}, nil
+	}
+	updateMockData = func(user *domain.User) (*domain.User, *util.ResponseError) {
+		return user, nil
+	}
+
+	user, err := UpdateUser(false, domain.User{Id: 2, FirstName: "Renamed", Email: "renamed@domain.com"}, "tester")
+	assert.Nil(t, err)
+	assert.NotNil(t, user)
+	assert.Equal(t, "Renamed", user.FirstName)
+	assert.Equal(t, "", user.LastName)
+	assert.Equal(t, "renamed@domain.com", user.Email)
+}
+
+func TestDeleteUserNotFound(t *testing.T) {
+
+	generateMockData = func(id int64) (*domain.User, *util.ResponseError) {
+		return nil, &util.ResponseError{
+			Message: "User not found",
+			Code:    404,
+		}
+	}
+
+	err := DeleteUser(11, 0, "tester")
+	assert.NotNil(t, err)
+	assert.Equal(t, 404, err.Code)
+}
+
+func TestListUsersInvalidSort(t *testing.T) {
+
+	users, next, err := ListUsers(domain.UserQuery{Sort: "password"})
+	assert.Nil(t, users)
+	assert.Equal(t, "", next)
+	assert.NotNil(t, err)
+	assert.Equal(t, 400, err.Code)
+}
+
+func TestUpdateUserVersionMismatch(t *testing.T) {
+
+	generateMockData = func(id int64) (*domain.User, *util.ResponseError) {
+		return &domain.User{
+			Id:      uint64(id),
+			Email:   "test2@domain.com",
+			Version: 3,
+		}, nil
+	}
+	updateMockData = func(user *domain.User) (*domain.User, *util.ResponseError) {
+		return user, nil
+	}
+
+	user, err := UpdateUser(true, domain.User{Id: 2, FirstName: "Stale", Version: 2}, "tester")
+	assert.Nil(t, user)
+	assert.NotNil(t, err)
+	assert.Equal(t, 412, err.Code)
+
+	user, err = UpdateUser(true, domain.User{Id: 2, FirstName: "Fresh", Version: 3}, "tester")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), user.Version)
+}
+
+func TestSearchUsersEmptyQuery(t *testing.T) {
+
+	matches, err := SearchUsers("  ", 0)
+	assert.Nil(t, matches)
+	assert.NotNil(t, err)
+	assert.Equal(t, 400, err.Code)
+}
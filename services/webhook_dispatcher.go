@@ -0,0 +1,240 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookIdHeader        = "X-Webhook-Id"
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+	webhookSignatureHeader = "X-Webhook-Signature"
+
+	webhookPollInterval = time.Second
+	webhookBatchSize    = 50
+	webhookMaxAttempts  = 5
+	webhookBackoff      = 2 * time.Second
+	webhookTimeout      = 10 * time.Second
+	webhookWorkers      = 4
+	webhookServeTimeout = 30 * time.Second
+)
+
+// webhookDispatcher reads the outbox and delivers the events to every webhook in order. A failed
+// delivery holds back the following events of that webhook until it is retried, with an
+// exponential backoff, and after maxAttempts the event is given up (it stays in the delivery log).
+// Retry state lives in memory, after a restart the pending event starts again from attempt one.
+// The webhooks are served by a few workers and each one only gets serveTimeout per tick, so a
+// slow endpoint delays its own events but not the ones of the other webhooks.
+type webhookDispatcher struct {
+	client       *http.Client
+	backoff      time.Duration
+	maxAttempts  int
+	workers      int
+	serveTimeout time.Duration
+	mu           sync.Mutex
+	retries      map[uint64]webhookRetry
+}
+
+type webhookRetry struct {
+	attempts    int
+	nextAttempt time.Time
+}
+
+func newWebhookDispatcher(backoff time.Duration) *webhookDispatcher {
+
+	return &webhookDispatcher{
+		client:       &http.Client{Timeout: webhookTimeout},
+		backoff:      backoff,
+		maxAttempts:  webhookMaxAttempts,
+		workers:      webhookWorkers,
+		serveTimeout: webhookServeTimeout,
+		retries:      make(map[uint64]webhookRetry),
+	}
+}
+
+// StartWebhookDispatcher delivers the events in the background for as long as the process lives.
+func StartWebhookDispatcher() {
+
+	dispatcher := newWebhookDispatcher(webhookBackoff)
+
+	go func() {
+		ticker := time.NewTicker(webhookPollInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			dispatcher.dispatch()
+		}
+	}()
+}
+
+func (d *webhookDispatcher) dispatch() {
+
+	webhooks, err := domain.WebhookDao.ListWebhooks()
+	if err != nil {
+		return
+	}
+
+	d.pruneRetries(webhooks)
+
+	pending := make(chan *domain.Webhook)
+	var workers sync.WaitGroup
+	for i := 0; i < d.workers && i < len(webhooks); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for webhook := range pending {
+				d.serve(webhook)
+			}
+		}()
+	}
+
+	for i := range webhooks {
+		pending <- &webhooks[i]
+	}
+	close(pending)
+	workers.Wait()
+}
+
+// pruneRetries forgets the retry state of the webhooks deleted since the last tick.
+func (d *webhookDispatcher) pruneRetries(webhooks []domain.Webhook) {
+
+	listed := make(map[uint64]bool, len(webhooks))
+	for _, webhook := range webhooks {
+		listed[webhook.Id] = true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id := range d.retries {
+		if !listed[id] {
+			delete(d.retries, id)
+		}
+	}
+}
+
+func (d *webhookDispatcher) serve(webhook *domain.Webhook) {
+
+	d.mu.Lock()
+	retry := d.retries[webhook.Id]
+	d.mu.Unlock()
+
+	if time.Now().Before(retry.nextAttempt) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.serveTimeout)
+	defer cancel()
+
+	changes, err := domain.UserDao.ListChanges(webhook.LastEventId, webhookBatchSize)
+	if err != nil {
+		return
+	}
+
+	for _, change := range changes {
+
+		// out of time for this tick, the rest waits for the next one
+		if ctx.Err() != nil {
+			return
+		}
+
+		event := domain.NewUserEvent(change)
+
+		if webhook.Subscribes(event.Type) {
+
+			delivery := d.deliver(ctx, webhook, event, retry.attempts+1)
+			domain.WebhookDao.AddDelivery(&delivery)
+
+			if !delivery.Success && delivery.Attempt < d.maxAttempts {
+				retry = webhookRetry{
+					attempts:    delivery.Attempt,
+					nextAttempt: time.Now().Add(d.backoff << uint(delivery.Attempt-1)),
+				}
+				d.mu.Lock()
+				d.retries[webhook.Id] = retry
+				d.mu.Unlock()
+				return
+			}
+		}
+
+		retry = webhookRetry{}
+		d.mu.Lock()
+		delete(d.retries, webhook.Id)
+		d.mu.Unlock()
+
+		if err := domain.WebhookDao.AdvanceWebhook(int64(webhook.Id), event.Id); err != nil {
+			// deleted while being served
+			return
+		}
+		webhook.LastEventId = event.Id
+	}
+}
+
+func (d *webhookDispatcher) deliver(ctx context.Context, webhook *domain.Webhook, event domain.UserEvent, attempt int) domain.WebhookDelivery {
+
+	delivery := domain.WebhookDelivery{
+		WebhookId:   webhook.Id,
+		EventId:     event.Id,
+		EventType:   event.Type,
+		Attempt:     attempt,
+		DeliveredAt: time.Now().UTC(),
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	request, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	request = request.WithContext(ctx)
+
+	timestamp := strconv.FormatInt(delivery.DeliveredAt.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhookEventHeader, event.Type)
+	request.Header.Set(webhookIdHeader, strconv.FormatUint(event.Id, 10))
+	request.Header.Set(webhookTimestampHeader, timestamp)
+	request.Header.Set(webhookSignatureHeader, signWebhookPayload(webhook.Secret, timestamp, body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	response.Body.Close()
+
+	delivery.StatusCode = response.StatusCode
+	delivery.Success = response.StatusCode >= 200 && response.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = fmt.Sprintf("unexpected status %d", response.StatusCode)
+	}
+
+	return delivery
+}
+
+// signWebhookPayload signs the timestamp together with the body, so a captured request cannot be
+// replayed later with a fresh timestamp. Receivers compute the same HMAC with their secret.
+func signWebhookPayload(secret string, timestamp string, body []byte) string {
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"encoding/json"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookDispatcherRetriesAndSigns(t *testing.T) {
+
+	defer useMemoryDao(t)()
+
+	status := http.StatusInternalServerError
+	var received []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	webhook, err := CreateWebhook(domain.WebhookRequest{Url: server.URL}, "admin")
+	assert.Nil(t, err)
+	defer DeleteWebhook(int64(webhook.Id))
+
+	user, err := CreateUser(domain.User{Email: "hooked@domain.com"}, "creator")
+	assert.Nil(t, err)
+
+	dispatcher := newWebhookDispatcher(0)
+	dispatcher.maxAttempts = 2
+
+	dispatcher.dispatch()
+	stored, _ := domain.WebhookDao.GetWebhook(int64(webhook.Id))
+	assert.Equal(t, webhook.LastEventId, stored.LastEventId)
+
+	status = http.StatusNoContent
+	dispatcher.dispatch()
+	assert.Len(t, received, 2)
+
+	var event domain.UserEvent
+	assert.Nil(t, json.Unmarshal(bodies[1], &event))
+	assert.Equal(t, domain.UserCreatedEvent, event.Type)
+	assert.Equal(t, user.Id, event.UserId)
+	assert.Equal(t, domain.UserCreatedEvent, received[1].Header.Get(webhookEventHeader))
+	assert.Equal(t, signWebhookPayload(webhook.Secret, received[1].Header.Get(webhookTimestampHeader), bodies[1]),
+		received[1].Header.Get(webhookSignatureHeader))
+
+	stored, _ = domain.WebhookDao.GetWebhook(int64(webhook.Id))
+	assert.Equal(t, event.Id, stored.LastEventId)
+
+	// an event is given up after the last attempt, the following ones still get delivered
+	status = http.StatusBadGateway
+	assert.Nil(t, DeleteUser(int64(user.Id), 0, "remover"))
+	dispatcher.dispatch()
+	dispatcher.dispatch()
+	stored, _ = domain.WebhookDao.GetWebhook(int64(webhook.Id))
+	assert.Equal(t, event.Id+1, stored.LastEventId)
+
+	deliveries, err := GetWebhookDeliveries(int64(webhook.Id), 0)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 4)
+	assert.Equal(t, domain.UserDeletedEvent, deliveries[0].EventType)
+	assert.Equal(t, 2, deliveries[0].Attempt)
+	assert.False(t, deliveries[0].Success)
+	assert.True(t, deliveries[2].Success)
+}
+
+func TestWebhookDispatcherSlowEndpointDoesNotBlockOthers(t *testing.T) {
+
+	defer useMemoryDao(t)()
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer fast.Close()
+
+	slowWebhook, err := CreateWebhook(domain.WebhookRequest{Url: slow.URL}, "admin")
+	assert.Nil(t, err)
+	defer DeleteWebhook(int64(slowWebhook.Id))
+	fastWebhook, err := CreateWebhook(domain.WebhookRequest{Url: fast.URL}, "admin")
+	assert.Nil(t, err)
+	defer DeleteWebhook(int64(fastWebhook.Id))
+
+	_, err = CreateUser(domain.User{Email: "slow-hook@domain.com"}, "creator")
+	assert.Nil(t, err)
+
+	dispatcher := newWebhookDispatcher(time.Hour)
+	dispatcher.serveTimeout = 100 * time.Millisecond
+
+	started := time.Now()
+	dispatcher.dispatch()
+	assert.True(t, time.Since(started) < 5*time.Second)
+
+	stored, _ := domain.WebhookDao.GetWebhook(int64(fastWebhook.Id))
+	assert.True(t, stored.LastEventId > fastWebhook.LastEventId)
+	stored, _ = domain.WebhookDao.GetWebhook(int64(slowWebhook.Id))
+	assert.Equal(t, slowWebhook.LastEventId, stored.LastEventId)
+	assert.Contains(t, dispatcher.retries, slowWebhook.Id)
+
+	// the retry state goes away with the webhook
+	assert.Nil(t, DeleteWebhook(int64(slowWebhook.Id)))
+	dispatcher.dispatch()
+	assert.NotContains(t, dispatcher.retries, slowWebhook.Id)
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/util"
+	"net/http"
+)
+
+const (
+	webhookSecretBytes     = 32
+	defaultDeliveriesLimit = 20
+)
+
+// CreateWebhook registers a subscriber for the events that happen from now on.
+func CreateWebhook(request domain.WebhookRequest, actor string) (*domain.CreatedWebhook, *util.ResponseError) {
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, &util.ResponseError{
+			Message: "could not generate webhook secret",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	latest, err := domain.UserDao.LatestChangeId()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := domain.WebhookDao.CreateWebhook(&domain.Webhook{
+		Url:         request.Url,
+		Events:      request.Events,
+		Secret:      hex.EncodeToString(secret),
+		LastEventId: latest,
+		CreatedBy:   actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatedWebhook{Webhook: *created, Secret: created.Secret}, nil
+}
+
+func ListWebhooks() ([]domain.Webhook, *util.ResponseError) {
+
+	return domain.WebhookDao.ListWebhooks()
+}
+
+func DeleteWebhook(webhookId int64) *util.ResponseError {
+
+	return domain.WebhookDao.DeleteWebhook(webhookId)
+}
+
+func GetWebhookDeliveries(webhookId int64, limit int) ([]domain.WebhookDelivery, *util.ResponseError) {
+
+	if _, err := domain.WebhookDao.GetWebhook(webhookId); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+
+	if limit > domain.MaxListLimit {
+		limit = domain.MaxListLimit
+	}
+
+	return domain.WebhookDao.ListDeliveries(webhookId, limit)
+}
@@ -1,17 +1,36 @@
 package app
 
 import (
-	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/src/api/provider/github_provider"
+	"os"
 )
 
-var ginHttp = gin.Default()
+const (
+	// jwtSecretKey must hold the same secret the user service signs its tokens with.
+	jwtSecretKey = "JWT_SECRET"
+	// api keys are read from the user store, it has to be the sqlite one shared with the user service
+	// unless both apis run in the same process.
+	userStoreKey         = "USER_STORE"
+	userStoreLocationKey = "USER_STORE_PATH"
+	// GitHub Enterprise Server deployments point this at their instance, github.com by default.
+	githubBaseURLKey = "GITHUB_BASE_URL"
+)
+
+// Configure points the repository api at the GitHub of the deployment, failing on invalid urls.
+func Configure() error {
 
-func StartApp() {
+	return github_provider.Configure(os.Getenv(githubBaseURLKey))
+}
 
-	mapUrls()
+// ConfigureStores prepares the stores the repository api reads callers from. When the user api runs
+// in the same process its own configuration already covers them.
+func ConfigureStores() {
 
-	if err := ginHttp.Run(":8081"); err != nil {
+	if err := domain.ConfigureUserDao(os.Getenv(userStoreKey), os.Getenv(userStoreLocationKey)); err != nil {
 		panic(err)
 	}
 
+	services.ConfigureAuth(os.Getenv(jwtSecretKey))
 }
@@ -1,10 +1,31 @@
 package app
 
-import "github.com/leandrotula/golangmicroservice/src/api/controller"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/controllers"
+	"github.com/leandrotula/golangmicroservice/src/api/controller"
+	"net/http"
+)
 
-func mapUrls() {
+var repositoryPolicy = authorization.Policy{
+	authorization.Route(http.MethodPost, "/repository"):                authorization.CreateRepositories,
+	authorization.Route(http.MethodPost, "/repository/from-template"):  authorization.CreateRepositories,
+	authorization.Route(http.MethodPost, "/repositories"):              authorization.CreateRepositories,
+	authorization.Route(http.MethodPost, "/orgs/:org/repository"):      authorization.CreateRepositories,
+	authorization.Route(http.MethodGet, "/repository/:id/owner"):       authorization.ReadUsers,
+	authorization.Route(http.MethodDelete, "/repository/:owner/:name"): authorization.DeleteRepositories,
+}
+
+// MapUrls registers the repository api under router, whatever prefix the router group has.
+func MapUrls(router *gin.RouterGroup) {
 
-	ginHttp.GET("/health", controller.Up)
-	ginHttp.POST("/repository", controller.CreateRepo)
-	ginHttp.POST("/repositories", controller.CreateRepos)
+	authorized := router.Group("", controllers.AuthenticateService,
+		authorization.Authorize(repositoryPolicy.Prefixed(router.BasePath()), controller.Forbidden))
+	authorized.POST("/repository", controller.CreateRepo)
+	authorized.POST("/repository/from-template", controller.CreateRepoFromTemplate)
+	authorized.POST("/repositories", controller.CreateRepos)
+	authorized.POST("/orgs/:org/repository", controller.CreateOrgRepo)
+	authorized.GET("/repository/:id/owner", controller.GetRepositoryOwner)
+	authorized.DELETE("/repository/:owner/:name", controller.DeleteRepo)
 }
@@ -61,6 +61,49 @@ func Post(url string, body interface{}, headers http.Header) (*http.Response, er
 		return nil, err
 	}
 
+	return do(request, headers)
+}
+
+func Get(url string, headers http.Header) (*http.Response, error) {
+
+	if enableMock {
+		mockFound := mocks[getMockId(http.MethodGet, url)]
+		if mockFound == nil {
+			return nil, errors.New("could not find a valid mock")
+		}
+
+		return mockFound.Response, mockFound.Err
+	}
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return do(request, headers)
+}
+
+func Delete(url string, headers http.Header) (*http.Response, error) {
+
+	if enableMock {
+		mockFound := mocks[getMockId(http.MethodDelete, url)]
+		if mockFound == nil {
+			return nil, errors.New("could not find a valid mock")
+		}
+
+		return mockFound.Response, mockFound.Err
+	}
+
+	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return do(request, headers)
+}
+
+func do(request *http.Request, headers http.Header) (*http.Response, error) {
+
 	timeout := 2 * time.Second
 	client := http.Client{
 		Timeout: timeout,
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/problem"
+	"net/http"
+)
+
+// Forbidden is how the repository api answers callers without the permission a route requires.
+func Forbidden(c *gin.Context) {
+
+	problem.Write(c, problem.New(http.StatusForbidden, "forbidden"))
+}
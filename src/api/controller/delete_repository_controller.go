@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/problem"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/src/api/service"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	// confirmDeleteHeader has to repeat the full name of the repository being deleted.
+	confirmDeleteHeader = "X-Confirm-Delete"
+	// overrideOwnershipHeader lets admins delete repositories not created through this service.
+	overrideOwnershipHeader = "X-Override-Ownership"
+)
+
+func DeleteRepo(c *gin.Context) {
+
+	owner, name := c.Param("owner"), c.Param("name")
+	fullName := owner + "/" + name
+
+	confirmation := c.GetHeader(confirmDeleteHeader)
+	if confirmation == "" {
+
+		problem.Write(c, problem.New(http.StatusPreconditionRequired, confirmDeleteHeader+" must name the repository to delete"))
+		return
+	}
+
+	if !strings.EqualFold(confirmation, fullName) {
+
+		problem.Write(c, problem.New(http.StatusPreconditionFailed, confirmDeleteHeader+" does not match "+fullName))
+		return
+	}
+
+	override := c.GetHeader(overrideOwnershipHeader) == "true"
+	if override && !authorization.CallerHasPermission(c, authorization.ManageRepositories) {
+
+		problem.Write(c, problem.New(http.StatusForbidden, "only admins can override the ownership guard"))
+		return
+	}
+
+	recorded, err := services.GetRepositoryByFullName(fullName)
+	if err != nil {
+
+		if err.Code != http.StatusNotFound {
+			problem.WriteResponseError(c, err)
+			return
+		}
+
+		if !override {
+			problem.Write(c, problem.New(http.StatusForbidden, "repository "+fullName+" was not created through this service"))
+			return
+		}
+	}
+
+	// the name may have been reused by a repository this service did not create
+	if recorded != nil && !override {
+
+		liveId, apiError := service.DeleteRepoOperation.RepositoryId(owner, name)
+		if apiError != nil {
+			problem.WriteApiError(c, apiError)
+			return
+		}
+
+		if liveId != recorded.RepositoryId {
+			problem.Write(c, problem.New(http.StatusForbidden, "repository "+fullName+" is not the one created through this service"))
+			return
+		}
+	}
+
+	if apiError := service.DeleteRepoOperation.DeleteRepo(owner, name); apiError != nil {
+
+		problem.WriteApiError(c, apiError)
+		return
+	}
+
+	// the repository is gone already, a stale owner is only logged
+	if recorded != nil {
+		if err := services.ForgetRepository(recorded.RepositoryId); err != nil {
+			log.Printf("could not forget the owner of repository %d: %s", recorded.RepositoryId, err.Message)
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/src/api/client"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// liveRepositoryId is the id GitHub answers with for every repository in these tests.
+const liveRepositoryId = 5151
+
+// deleteRepo returns the status set by the handler, c.Status alone is not written to the recorder.
+func deleteRepo(role string, name string, headers map[string]string) int {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/repository/octocat/"+name, nil)
+	c.Params = gin.Params{{Key: "owner", Value: "octocat"}, {Key: "name", Value: name}}
+	c.Set(authorization.RoleKey, role)
+	for header, value := range headers {
+		c.Request.Header.Set(header, value)
+	}
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodGet,
+		Url:        "https://api.github.com/repos/octocat/" + name,
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"id": %d}`, liveRepositoryId))),
+			StatusCode: http.StatusOK,
+		},
+	})
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodDelete,
+		Url:        "https://api.github.com/repos/octocat/" + name,
+		Response:   &http.Response{Body: ioutil.NopCloser(strings.NewReader("")), StatusCode: http.StatusNoContent},
+	})
+
+	DeleteRepo(c)
+
+	return c.Writer.Status()
+}
+
+func TestDeleteRepoRequiresConfirmation(t *testing.T) {
+
+	assert.EqualValues(t, http.StatusPreconditionRequired, deleteRepo(domain.RoleOperator, "guarded", nil))
+
+	status := deleteRepo(domain.RoleOperator, "guarded", map[string]string{confirmDeleteHeader: "octocat/other"})
+	assert.EqualValues(t, http.StatusPreconditionFailed, status)
+}
+
+func TestDeleteRepoOwnershipGuard(t *testing.T) {
+
+	confirm := map[string]string{confirmDeleteHeader: "octocat/foreign"}
+	assert.EqualValues(t, http.StatusForbidden, deleteRepo(domain.RoleAdmin, "foreign", confirm))
+
+	override := map[string]string{confirmDeleteHeader: "octocat/foreign", overrideOwnershipHeader: "true"}
+	assert.EqualValues(t, http.StatusForbidden, deleteRepo(domain.RoleOperator, "foreign", override))
+	assert.EqualValues(t, http.StatusNoContent, deleteRepo(domain.RoleAdmin, "foreign", override))
+}
+
+func TestDeleteRepoForgetsOwner(t *testing.T) {
+
+	_, err := domain.RepositoryOwnerDao.SaveRepositoryOwner(&domain.RepositoryOwner{
+		RepositoryId: liveRepositoryId, Name: "owned", FullName: "octocat/owned", UserId: 1})
+	assert.Nil(t, err)
+
+	status := deleteRepo(domain.RoleOperator, "owned", map[string]string{confirmDeleteHeader: "OctoCat/Owned"})
+	assert.EqualValues(t, http.StatusNoContent, status)
+
+	_, err = domain.RepositoryOwnerDao.GetRepositoryOwner(liveRepositoryId)
+	assert.EqualValues(t, http.StatusNotFound, err.Code)
+}
+
+func TestDeleteRepoRejectsReplacedRepository(t *testing.T) {
+
+	_, err := domain.RepositoryOwnerDao.SaveRepositoryOwner(&domain.RepositoryOwner{
+		RepositoryId: 6161, Name: "replaced", FullName: "octocat/replaced", UserId: 1})
+	assert.Nil(t, err)
+
+	confirm := map[string]string{confirmDeleteHeader: "octocat/replaced"}
+	assert.EqualValues(t, http.StatusForbidden, deleteRepo(domain.RoleOperator, "replaced", confirm))
+
+	_, err = domain.RepositoryOwnerDao.GetRepositoryOwner(6161)
+	assert.Nil(t, err)
+
+	override := map[string]string{confirmDeleteHeader: "octocat/replaced", overrideOwnershipHeader: "true"}
+	assert.EqualValues(t, http.StatusNoContent, deleteRepo(domain.RoleAdmin, "replaced", override))
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/leandrotula/golangmicroservice/problem"
 	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
 	"github.com/leandrotula/golangmicroservice/src/api/repository"
 	"github.com/leandrotula/golangmicroservice/src/api/service"
@@ -14,21 +15,74 @@ func CreateRepo(c *gin.Context) {
 	var request repository.ApiRequest
 	if bindError := c.ShouldBindBodyWith(&request, binding.JSON); bindError != nil {
 
-		errors := errorApi.NewBadRequestError("invalid json body")
-		c.JSON(errors.Status(), errors)
+		problem.WriteApiError(c, errorApi.NewBadRequestError("invalid json body"))
 
 		return
 
 	}
 
-	response, err := service.CreateRepoOperation.CreateRepo(&request)
+	createRepo(c, &request)
+}
+
+// CreateOrgRepo creates the repository in the organization of the path.
+func CreateOrgRepo(c *gin.Context) {
+
+	var request repository.ApiRequest
+	if bindError := c.ShouldBindBodyWith(&request, binding.JSON); bindError != nil {
+
+		problem.WriteApiError(c, errorApi.NewBadRequestError("invalid json body"))
+
+		return
+
+	}
+
+	org := c.Param("org")
+	if request.Org != "" && request.Org != org {
+
+		problem.WriteApiError(c, errorApi.NewBadRequestError("org in the body does not match the path"))
+
+		return
+	}
+	request.Org = org
+
+	createRepo(c, &request)
+}
+
+// CreateRepoFromTemplate generates a repository from a template repository.
+func CreateRepoFromTemplate(c *gin.Context) {
+
+	var request repository.TemplateRequest
+	if bindError := c.ShouldBindBodyWith(&request, binding.JSON); bindError != nil {
+
+		problem.WriteApiError(c, errorApi.NewBadRequestError("invalid json body"))
+
+		return
+
+	}
+
+	response, err := service.CreateRepoOperation.CreateRepoFromTemplate(&request)
 
 	if err != nil {
 
-		c.JSON(err.Status(), err)
+		problem.WriteApiError(c, err)
 		return
 	}
 
+	recordOwner(c, response)
+	c.JSON(http.StatusCreated, response)
+}
+
+func createRepo(c *gin.Context, request *repository.ApiRequest) {
+
+	response, err := service.CreateRepoOperation.CreateRepo(request)
+
+	if err != nil {
+
+		problem.WriteApiError(c, err)
+		return
+	}
+
+	recordOwner(c, response)
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -37,8 +91,7 @@ func CreateRepos(c *gin.Context) {
 	var request []repository.ApiRequest
 	if bindError := c.ShouldBindBodyWith(&request, binding.JSON); bindError != nil {
 
-		errors := errorApi.NewBadRequestError("invalid json body")
-		c.JSON(errors.Status(), errors)
+		problem.WriteApiError(c, errorApi.NewBadRequestError("invalid json body"))
 
 		return
 
@@ -48,9 +101,45 @@ func CreateRepos(c *gin.Context) {
 
 	if err != nil {
 
-		c.JSON(err.Status(), err)
+		problem.WriteApiError(c, err)
 		return
 	}
 
-	c.JSON(response.StatusCode, response)
-}
\ No newline at end of file
+	for _, result := range response.Results {
+		if result.Response != nil {
+			recordOwner(c, result.Response)
+		}
+	}
+
+	c.JSON(response.StatusCode, toCreateReposResult(response))
+}
+
+// createReposResult is CreateReposResponse with the error of each repository as a problem.
+type createReposResult struct {
+	StatusCode int                      `json:"status_code"`
+	Results    []createRepositoryResult `json:"results"`
+}
+
+type createRepositoryResult struct {
+	Response *repository.ApiResponse `json:"response"`
+	Error    *problem.Problem        `json:"error"`
+}
+
+func toCreateReposResult(response repository.CreateReposResponse) createReposResult {
+
+	result := createReposResult{
+		StatusCode: response.StatusCode,
+		Results:    make([]createRepositoryResult, 0, len(response.Results)),
+	}
+
+	for _, created := range response.Results {
+
+		converted := createRepositoryResult{Response: created.Response}
+		if created.Error != nil {
+			converted.Error = problem.FromApiError(created.Error)
+		}
+		result.Results = append(result.Results, converted)
+	}
+
+	return result
+}
@@ -1,9 +1,10 @@
 package controller
 
 import (
+	"encoding/json"
 	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/problem"
 	"github.com/leandrotula/golangmicroservice/src/api/client"
-	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
@@ -30,9 +31,12 @@ func TestCreateRepoWithInvalidName(t *testing.T) {
 	CreateRepo(c)
 
 	assert.EqualValues(t, http.StatusBadRequest, response.Code)
-	apiError, _ := errorApi.DeserializeByteResponse(response.Body.Bytes())
-	assert.NotNil(t, apiError)
-	assert.EqualValues(t, "invalid input name", apiError.Message())
+	assert.EqualValues(t, problem.ContentType, response.Header().Get("Content-Type"))
+	var body problem.Problem
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.EqualValues(t, http.StatusBadRequest, body.Status)
+	assert.EqualValues(t, "invalid input name", body.Detail)
+	assert.EqualValues(t, "/repositories", body.Instance)
 
 }
 
@@ -47,9 +51,12 @@ func TestCreateRepoWithInvalidJsonBody(t *testing.T) {
 	CreateRepo(c)
 
 	assert.EqualValues(t, http.StatusBadRequest, response.Code)
-	apiError, _ := errorApi.DeserializeByteResponse(response.Body.Bytes())
-	assert.NotNil(t, apiError)
-	assert.EqualValues(t, "invalid json body", apiError.Message())
+	assert.EqualValues(t, problem.ContentType, response.Header().Get("Content-Type"))
+	var body problem.Problem
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.EqualValues(t, http.StatusBadRequest, body.Status)
+	assert.EqualValues(t, "invalid json body", body.Detail)
+	assert.EqualValues(t, "/repositories", body.Instance)
 
 }
 
@@ -77,3 +84,61 @@ func TestCreateRepoSuccess(t *testing.T) {
 	assert.EqualValues(t, http.StatusCreated, response.Code)
 
 }
+
+func TestCreateReposReportsProblems(t *testing.T) {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+	request, _ := http.NewRequest(http.MethodPost, "/repositories", strings.NewReader(`[{"name":""}]`))
+	c.Request = request
+
+	CreateRepos(c)
+
+	assert.EqualValues(t, http.StatusBadRequest, response.Code)
+	var body createReposResult
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Len(t, body.Results, 1)
+	assert.Nil(t, body.Results[0].Response)
+	assert.EqualValues(t, http.StatusBadRequest, body.Results[0].Error.Status)
+	assert.EqualValues(t, "invalid input name", body.Results[0].Error.Detail)
+}
+
+func TestCreateOrgRepoRejectsMismatchedOrg(t *testing.T) {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+	request, _ := http.NewRequest(http.MethodPost, "/orgs/octo-org/repository",
+		strings.NewReader(`{"name":"repo","org":"other-org"}`))
+	c.Request = request
+	c.Params = gin.Params{{Key: "org", Value: "octo-org"}}
+
+	CreateOrgRepo(c)
+
+	assert.EqualValues(t, http.StatusBadRequest, response.Code)
+	var body problem.Problem
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.EqualValues(t, "org in the body does not match the path", body.Detail)
+}
+
+func TestCreateOrgRepoSuccess(t *testing.T) {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+	request, _ := http.NewRequest(http.MethodPost, "/orgs/octo-org/repository", strings.NewReader(`{"name":"repo"}`))
+	c.Request = request
+	c.Params = gin.Params{{Key: "org", Value: "octo-org"}}
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodPost,
+		Url:        "https://api.github.com/orgs/octo-org/repos",
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id":7,"name":"repo","full_name":"octo-org/repo"}`)),
+			StatusCode: http.StatusCreated,
+		},
+	})
+
+	CreateOrgRepo(c)
+
+	assert.EqualValues(t, http.StatusCreated, response.Code)
+}
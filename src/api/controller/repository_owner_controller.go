@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/services"
+	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
+	"github.com/leandrotula/golangmicroservice/src/api/repository"
+	"net/http"
+	"strconv"
+)
+
+func GetRepositoryOwner(c *gin.Context) {
+
+	repositoryId, parserError := strconv.ParseInt(c.Param("id"), 10, 64)
+	if parserError != nil {
+
+		errors := errorApi.NewBadRequestError("invalid repository id")
+		c.JSON(errors.Status(), errors)
+		return
+	}
+
+	owner, err := services.GetRepositoryOwner(repositoryId)
+
+	if err != nil {
+
+		apiError := errorApi.NewApiError(err.Message, err.Code)
+		c.JSON(apiError.Status(), apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, owner)
+}
+
+func recordOwner(c *gin.Context, response *repository.ApiResponse) {
+
+	services.RecordRepositoryOwner(int64(response.ID), response.Name, response.FullName,
+		c.GetInt64(authorization.UserIdKey), c.GetInt64(authorization.ApiKeyIdKey))
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/leandrotula/golangmicroservice/authorization"
+	"github.com/leandrotula/golangmicroservice/domain"
+	"github.com/leandrotula/golangmicroservice/src/api/client"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateRepoRecordsOwner(t *testing.T) {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/repository", strings.NewReader(`{"name":"owned-repo"}`))
+	c.Set(authorization.UserIdKey, int64(1))
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodPost,
+		Url:        "https://api.github.com/user/repos",
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id":4242,"name":"owned-repo","full_name":"octocat/owned-repo"}`)),
+			StatusCode: http.StatusCreated,
+		},
+	})
+
+	CreateRepo(c)
+	assert.EqualValues(t, http.StatusCreated, response.Code)
+
+	response = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(response)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/repository/4242/owner", nil)
+	c.Params = gin.Params{{Key: "id", Value: "4242"}}
+
+	GetRepositoryOwner(c)
+
+	assert.EqualValues(t, http.StatusOK, response.Code)
+	var owner domain.User
+	assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &owner))
+	assert.EqualValues(t, 1, owner.Id)
+}
+
+func TestGetRepositoryOwnerUnknown(t *testing.T) {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/repository/1/owner", nil)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	GetRepositoryOwner(c)
+
+	assert.EqualValues(t, http.StatusNotFound, response.Code)
+}
+
+func TestCreateRepoFromTemplateRecordsOwner(t *testing.T) {
+
+	response := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(response)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/repository/from-template",
+		strings.NewReader(`{"template_owner":"octo-org","template_repo":"golden-template","name":"templated-repo"}`))
+	c.Set(authorization.UserIdKey, int64(1))
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodPost,
+		Url:        "https://api.github.com/repos/octo-org/golden-template/generate",
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id":4343,"name":"templated-repo","full_name":"octocat/templated-repo"}`)),
+			StatusCode: http.StatusCreated,
+		},
+	})
+
+	CreateRepoFromTemplate(c)
+
+	assert.EqualValues(t, http.StatusCreated, response.Code)
+
+	owner, err := domain.RepositoryOwnerDao.GetRepositoryOwner(4343)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, owner.UserId)
+}
@@ -1,11 +1,21 @@
 package github
 
 type CreateRepositoryRequestGithub struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Homepage    string `json:"homepage"`
-	Private     bool   `json:"private"`
-	HasIssues   bool   `json:"has_issues"`
-	HasProjects bool   `json:"has_projects"`
-	HasWiki     bool   `json:"has_wiki"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	Homepage            string `json:"homepage"`
+	Private             bool   `json:"private"`
+	Visibility          string `json:"visibility,omitempty"`
+	HasIssues           bool   `json:"has_issues"`
+	HasProjects         bool   `json:"has_projects"`
+	HasWiki             bool   `json:"has_wiki"`
+	IsTemplate          bool   `json:"is_template"`
+	AutoInit            bool   `json:"auto_init"`
+	GitignoreTemplate   string `json:"gitignore_template,omitempty"`
+	LicenseTemplate     string `json:"license_template,omitempty"`
+	AllowSquashMerge    bool   `json:"allow_squash_merge"`
+	AllowMergeCommit    bool   `json:"allow_merge_commit"`
+	AllowRebaseMerge    bool   `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool   `json:"allow_auto_merge"`
+	DeleteBranchOnMerge bool   `json:"delete_branch_on_merge"`
 }
@@ -0,0 +1,9 @@
+package github
+
+type GenerateRepositoryRequestGithub struct {
+	Owner              string `json:"owner,omitempty"`
+	Name               string `json:"name"`
+	Description        string `json:"description,omitempty"`
+	IncludeAllBranches bool   `json:"include_all_branches"`
+	Private            bool   `json:"private"`
+}
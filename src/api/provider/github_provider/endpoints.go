@@ -0,0 +1,69 @@
+package github_provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	DefaultBaseURL = "https://api.github.com/"
+
+	githubApiHost     = "api.github.com"
+	enterpriseApiPath = "api/v3/"
+)
+
+var (
+	baseURL = DefaultBaseURL
+)
+
+// Configure points the provider at another GitHub, an empty url keeps github.com. A GitHub
+// Enterprise Server can be given by its host alone, the api path is added when missing.
+func Configure(base string) error {
+
+	if base == "" {
+		baseURL = DefaultBaseURL
+		return nil
+	}
+
+	parsedBase, err := parseEndpoint(base)
+	if err != nil {
+		return err
+	}
+
+	if parsedBase.Host != githubApiHost && !strings.HasSuffix(parsedBase.Path, enterpriseApiPath) {
+		parsedBase.Path += enterpriseApiPath
+	}
+
+	baseURL = parsedBase.String()
+	return nil
+}
+
+func parseEndpoint(raw string) (*url.URL, error) {
+
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid github base url %q, it must be an absolute http(s) url", raw)
+	}
+
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return nil, fmt.Errorf("invalid github base url %q, it cannot have a query or a fragment", raw)
+	}
+
+	if !strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path += "/"
+	}
+
+	return parsed, nil
+}
+
+// BaseURL is the root every GitHub endpoint is built from.
+func BaseURL() string {
+
+	return baseURL
+}
+
+func endpoint(path string) string {
+
+	return baseURL + strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,37 @@
+package github_provider
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfigureDefaultsToGithub(t *testing.T) {
+
+	assert.Nil(t, Configure(""))
+	assert.EqualValues(t, "https://api.github.com/user/repos", endpoint(userRepositoriesPath))
+
+	assert.Nil(t, Configure("https://api.github.com"))
+	assert.EqualValues(t, DefaultBaseURL, BaseURL())
+}
+
+func TestConfigureEnterpriseServer(t *testing.T) {
+
+	defer Configure("")
+
+	assert.Nil(t, Configure("https://github.example.com"))
+	assert.EqualValues(t, "https://github.example.com/api/v3/user/repos", endpoint(userRepositoriesPath))
+
+	assert.Nil(t, Configure("https://github.example.com/api/v3"))
+	assert.EqualValues(t, "https://github.example.com/api/v3/", BaseURL())
+}
+
+func TestConfigureRejectsInvalidUrls(t *testing.T) {
+
+	defer Configure("")
+
+	assert.NotNil(t, Configure("github.example.com"))
+	assert.NotNil(t, Configure("ftp://github.example.com"))
+	assert.NotNil(t, Configure("https://github.example.com/?token=1"))
+	// a rejected configuration keeps the previous one
+	assert.EqualValues(t, DefaultBaseURL, BaseURL())
+}
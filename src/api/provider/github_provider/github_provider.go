@@ -7,19 +7,223 @@ import (
 	"github.com/leandrotula/golangmicroservice/src/api/domain/github"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 const (
-	githubURL string = "https://api.github.com/user/repos"
+	userRepositoriesPath = "user/repos"
+	orgRepositoriesPath  = "orgs/%s/repos"
+	generatePath         = "repos/%s/%s/generate"
+	repositoryPath       = "repos/%s/%s"
 )
 
 func CreatePostRepository(accessToken string, request github.CreateRepositoryRequestGithub)(*github.CreateRepositoryResponseGithub,
 	*github.ErrorResponseGithub, *github.UnprocessableEntityResponseGithub) {
 
+	return createRepository(endpoint(userRepositoriesPath), accessToken, request, nil)
+}
+
+// CreateOrgRepository creates the repository inside org. The token owner has to be a member allowed
+// to create repositories there, and the organization has to allow the requested visibility.
+func CreateOrgRepository(accessToken string, org string, request github.CreateRepositoryRequestGithub)(*github.CreateRepositoryResponseGithub,
+	*github.ErrorResponseGithub, *github.UnprocessableEntityResponseGithub) {
+
+	orgURL := endpoint(fmt.Sprintf(orgRepositoriesPath, url.PathEscape(org)))
+	response, errorResponse, unprocessable := createRepository(orgURL, accessToken, request, orgErrors(org))
+
+	if unprocessable != nil && rejectsVisibility(unprocessable) {
+		return nil, &github.ErrorResponseGithub{
+			Message: fmt.Sprintf("organization %s does not allow %s repositories", org, visibilityOf(request)),
+			StatusCode: http.StatusUnprocessableEntity,
+		}, nil
+	}
+
+	return response, errorResponse, unprocessable
+}
+
+// GenerateRepository creates a repository from the template repository templateOwner/templateRepo.
+func GenerateRepository(accessToken string, templateOwner string, templateRepo string,
+	request github.GenerateRepositoryRequestGithub)(*github.CreateRepositoryResponseGithub,
+	*github.ErrorResponseGithub, *github.UnprocessableEntityResponseGithub) {
+
+	generateURL := endpoint(fmt.Sprintf(generatePath, url.PathEscape(templateOwner), url.PathEscape(templateRepo)))
+	template := templateOwner + "/" + templateRepo
+
+	return createRepository(generateURL, accessToken, request, map[int]*github.ErrorResponseGithub{
+		http.StatusNotFound: {
+			Message: fmt.Sprintf("template repository %s does not exist or is not visible to the token owner", template),
+			StatusCode: http.StatusNotFound,
+		},
+		http.StatusForbidden: {
+			Message: fmt.Sprintf("the token owner is not allowed to generate repositories from %s", template),
+			StatusCode: http.StatusForbidden,
+		},
+	})
+}
+
+// GetRepository returns owner/name as GitHub currently knows it.
+func GetRepository(accessToken string, owner string, name string)(*github.CreateRepositoryResponseGithub,
+	*github.ErrorResponseGithub) {
+
+	headers := http.Header{}
+	headers.Set("Authorization", fmt.Sprintf("token %s", accessToken))
+
+	repositoryURL := endpoint(fmt.Sprintf(repositoryPath, url.PathEscape(owner), url.PathEscape(name)))
+	getResponse, getError := client.Get(repositoryURL, headers)
+
+	if getError != nil {
+
+		return nil, &github.ErrorResponseGithub{
+			Message: getError.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	if getResponse.Body != nil {
+		defer getResponse.Body.Close()
+	}
+
+	switch getResponse.StatusCode {
+
+	case http.StatusOK:
+		bytes, err := ioutil.ReadAll(getResponse.Body)
+		if err != nil {
+			return nil, &github.ErrorResponseGithub{
+				Message: "unable to read/process response",
+				StatusCode: http.StatusInternalServerError,
+			}
+		}
+
+		var repository github.CreateRepositoryResponseGithub
+		if errorMarshalling := json.Unmarshal(bytes, &repository); errorMarshalling != nil {
+			return nil, &github.ErrorResponseGithub{
+				Message: "parsing errorMarshalling response",
+				StatusCode: http.StatusInternalServerError,
+			}
+		}
+
+		return &repository, nil
+
+	case http.StatusUnauthorized:
+		return nil, &github.ErrorResponseGithub{
+			Message: "unauthorized access",
+			StatusCode: http.StatusUnauthorized,
+		}
+
+	case http.StatusNotFound:
+		return nil, &github.ErrorResponseGithub{
+			Message: fmt.Sprintf("repository %s/%s does not exist or is not visible to the token owner", owner, name),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	return nil, &github.ErrorResponseGithub{
+		Message: fmt.Sprintf("Got invalid status code %v", getResponse.StatusCode),
+		StatusCode: http.StatusInternalServerError,
+	}
+}
+
+// DeleteRepository deletes owner/name for good, the token needs the delete_repo scope.
+func DeleteRepository(accessToken string, owner string, name string) *github.ErrorResponseGithub {
+
+	headers := http.Header{}
+	headers.Set("Authorization", fmt.Sprintf("token %s", accessToken))
+
+	repositoryURL := endpoint(fmt.Sprintf(repositoryPath, url.PathEscape(owner), url.PathEscape(name)))
+	deleteResponse, deleteError := client.Delete(repositoryURL, headers)
+
+	if deleteError != nil {
+
+		return &github.ErrorResponseGithub{
+			Message: deleteError.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	if deleteResponse.Body != nil {
+		defer deleteResponse.Body.Close()
+	}
+
+	switch deleteResponse.StatusCode {
+
+	case http.StatusNoContent:
+		return nil
+
+	case http.StatusUnauthorized:
+		return &github.ErrorResponseGithub{
+			Message: "unauthorized access",
+			StatusCode: http.StatusUnauthorized,
+		}
+
+	case http.StatusForbidden:
+		return &github.ErrorResponseGithub{
+			Message: fmt.Sprintf("the token owner is not allowed to delete %s/%s", owner, name),
+			StatusCode: http.StatusForbidden,
+		}
+
+	case http.StatusNotFound:
+		return &github.ErrorResponseGithub{
+			Message: fmt.Sprintf("repository %s/%s does not exist or is not visible to the token owner", owner, name),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	return &github.ErrorResponseGithub{
+		Message: fmt.Sprintf("Got invalid status code %v", deleteResponse.StatusCode),
+		StatusCode: http.StatusInternalServerError,
+	}
+}
+
+// orgErrors explains the answers GitHub gives when the token owner cannot use the organization.
+func orgErrors(org string) map[int]*github.ErrorResponseGithub {
+
+	return map[int]*github.ErrorResponseGithub{
+		http.StatusNotFound: {
+			Message: fmt.Sprintf("organization %s does not exist or is not visible to the token owner", org),
+			StatusCode: http.StatusNotFound,
+		},
+		http.StatusForbidden: {
+			Message: fmt.Sprintf("the token owner is not allowed to create repositories in organization %s", org),
+			StatusCode: http.StatusForbidden,
+		},
+	}
+}
+
+func rejectsVisibility(unprocessable *github.UnprocessableEntityResponseGithub) bool {
+
+	if strings.Contains(strings.ToLower(unprocessable.Message), "visibility") {
+		return true
+	}
+
+	for _, cause := range unprocessable.Errors {
+		if cause.Field == "visibility" || strings.Contains(strings.ToLower(cause.Message), "visibility") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func visibilityOf(request github.CreateRepositoryRequestGithub) string {
+
+	if request.Visibility != "" {
+		return request.Visibility
+	}
+
+	if request.Private {
+		return "private"
+	}
+
+	return "public"
+}
+
+func createRepository(repositoriesURL string, accessToken string, request interface{},
+	statusErrors map[int]*github.ErrorResponseGithub)(*github.CreateRepositoryResponseGithub,
+	*github.ErrorResponseGithub, *github.UnprocessableEntityResponseGithub) {
+
 	headers := http.Header{}
 	headers.Set("Authorization", fmt.Sprintf("token %s", accessToken))
 
-	postResponse, postError := client.Post(githubURL, request, headers)
+	postResponse, postError := client.Post(repositoriesURL, request, headers)
 
 	if postError != nil {
 
@@ -28,6 +232,10 @@ func CreatePostRepository(accessToken string, request github.CreateRepositoryReq
 		}, nil
 	}
 
+	if statusError, present := statusErrors[postResponse.StatusCode]; present {
+		return nil, statusError, nil
+	}
+
 	switch postResponse.StatusCode {
 
 	case http.StatusInternalServerError:
@@ -49,7 +49,7 @@ func TestCreateAndProcessValidResponse(t *testing.T) {
 		Url: "https://api.github.com/user/repos",
 		Response:   &http.Response{
 			Body: ioutil.NopCloser(strings.NewReader("{\"id\":1296269,\"node_id\":\"MDEwOlJlcG9zaXRvcnkxMjk2MjY5\",\"name\":\"Hello-World\",\"full_name\":\"octocat/Hello-World\",\"owner\":{\"login\":\"octocat\",\"id\":1,\"node_id\":\"MDQ6VXNlcjE=\",\"avatar_url\":\"https://github.com/images/errorApi/octocat_happy.gif\",\"gravatar_id\":\"\",\"url\":\"https://api.github.com/users/octocat\",\"html_url\":\"https://github.com/octocat\",\"followers_url\":\"https://api.github.com/users/octocat/followers\",\"following_url\":\"https://api.github.com/users/octocat/following{/other_user}\",\"gists_url\":\"https://api.github.com/users/octocat/gists{/gist_id}\",\"starred_url\":\"https://api.github.com/users/octocat/starred{/owner}{/repo}\",\"subscriptions_url\":\"https://api.github.com/users/octocat/subscriptions\",\"organizations_url\":\"https://api.github.com/users/octocat/orgs\",\"repos_url\":\"https://api.github.com/users/octocat/repos\",\"events_url\":\"https://api.github.com/users/octocat/events{/privacy}\",\"received_events_url\":\"https://api.github.com/users/octocat/received_events\",\"type\":\"User\",\"site_admin\":false}}")),
-			StatusCode: http.StatusOK,
+			StatusCode: http.StatusCreated,
 		},
 		Err:        nil,
 	})
@@ -74,7 +74,7 @@ func TestCreateProcessNonValidSuccessResponse(t *testing.T) {
 		Url: "https://api.github.com/user/repos",
 		Response:   &http.Response{
 			Body: ioutil.NopCloser(strings.NewReader("{\"id\":\"1296269\",\"node_id\":\"MDEwOlJlcG9zaXRvcnkxMjk2MjY5\",\"name\":\"Hello-World\",\"full_name\":\"octocat/Hello-World\"}")),
-			StatusCode: http.StatusOK,
+			StatusCode: http.StatusCreated,
 		},
 		Err:        nil,
 	})
@@ -153,4 +153,133 @@ func TestCreateNonExpectedStatusCode(t *testing.T) {
 	assert.Nil(t, invalidResponse)
 	assert.EqualValues(t, "Got invalid status code 208", err.Message)
 
-}
\ No newline at end of file
+}
+func TestCreateOrgRepository(t *testing.T) {
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodPost,
+		Url:        "https://api.github.com/orgs/octo-org/repos",
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id":42,"name":"Hello-World","full_name":"octo-org/Hello-World"}`)),
+			StatusCode: http.StatusCreated,
+		},
+	})
+
+	response, err, invalidResponse := CreateOrgRepository("", "octo-org", github.CreateRepositoryRequestGithub{Name: "Hello-World"})
+	assert.Nil(t, err)
+	assert.Nil(t, invalidResponse)
+	assert.EqualValues(t, "octo-org/Hello-World", response.FullName)
+}
+
+func TestCreateOrgRepositoryErrors(t *testing.T) {
+
+	respond := func(statusCode int, body string) {
+		client.RestoreMockup()
+		client.AddMockBehavior(client.Mock{
+			HttpMethod: http.MethodPost,
+			Url:        "https://api.github.com/orgs/octo-org/repos",
+			Response:   &http.Response{Body: ioutil.NopCloser(strings.NewReader(body)), StatusCode: statusCode},
+		})
+	}
+
+	respond(http.StatusNotFound, `{"message":"Not Found"}`)
+	_, err, _ := CreateOrgRepository("", "octo-org", github.CreateRepositoryRequestGithub{Name: "repo"})
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode)
+	assert.EqualValues(t, "organization octo-org does not exist or is not visible to the token owner", err.Message)
+
+	respond(http.StatusForbidden, `{"message":"You need admin access to the organization before adding a repository to it."}`)
+	_, err, _ = CreateOrgRepository("", "octo-org", github.CreateRepositoryRequestGithub{Name: "repo"})
+	assert.EqualValues(t, http.StatusForbidden, err.StatusCode)
+
+	respond(http.StatusUnprocessableEntity, `{"message":"Repository creation failed.","errors":[{"resource":"Repository","code":"custom","field":"visibility","message":"visibility can't be private"}]}`)
+	_, err, _ = CreateOrgRepository("", "octo-org", github.CreateRepositoryRequestGithub{Name: "repo", Private: true})
+	assert.EqualValues(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.EqualValues(t, "organization octo-org does not allow private repositories", err.Message)
+
+	respond(http.StatusUnprocessableEntity, `{"message":"Repository creation failed.","errors":[{"resource":"Repository","code":"custom","field":"name","message":"name already exists on this account"}]}`)
+	_, err, invalidResponse := CreateOrgRepository("", "octo-org", github.CreateRepositoryRequestGithub{Name: "repo"})
+	assert.Nil(t, err)
+	assert.EqualValues(t, "name", invalidResponse.Errors[0].Field)
+}
+
+func TestGenerateRepository(t *testing.T) {
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodPost,
+		Url:        "https://api.github.com/repos/octo-org/golden-template/generate",
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id":43,"name":"new-service","full_name":"octo-org/new-service"}`)),
+			StatusCode: http.StatusCreated,
+		},
+	})
+
+	response, err, invalidResponse := GenerateRepository("", "octo-org", "golden-template",
+		github.GenerateRepositoryRequestGithub{Owner: "octo-org", Name: "new-service"})
+	assert.Nil(t, err)
+	assert.Nil(t, invalidResponse)
+	assert.EqualValues(t, "octo-org/new-service", response.FullName)
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		HttpMethod: http.MethodPost,
+		Url:        "https://api.github.com/repos/octo-org/golden-template/generate",
+		Response:   &http.Response{Body: ioutil.NopCloser(strings.NewReader(`{"message":"Not Found"}`)), StatusCode: http.StatusNotFound},
+	})
+
+	_, err, _ = GenerateRepository("", "octo-org", "golden-template", github.GenerateRepositoryRequestGithub{Name: "new-service"})
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode)
+	assert.EqualValues(t, "template repository octo-org/golden-template does not exist or is not visible to the token owner", err.Message)
+}
+
+func TestDeleteRepository(t *testing.T) {
+
+	respond := func(statusCode int) {
+		client.RestoreMockup()
+		client.AddMockBehavior(client.Mock{
+			HttpMethod: http.MethodDelete,
+			Url:        "https://api.github.com/repos/octocat/old-repo",
+			Response:   &http.Response{Body: ioutil.NopCloser(strings.NewReader("")), StatusCode: statusCode},
+		})
+	}
+
+	respond(http.StatusNoContent)
+	assert.Nil(t, DeleteRepository("", "octocat", "old-repo"))
+
+	respond(http.StatusForbidden)
+	err := DeleteRepository("", "octocat", "old-repo")
+	assert.EqualValues(t, http.StatusForbidden, err.StatusCode)
+	assert.EqualValues(t, "the token owner is not allowed to delete octocat/old-repo", err.Message)
+
+	respond(http.StatusNotFound)
+	assert.EqualValues(t, http.StatusNotFound, DeleteRepository("", "octocat", "old-repo").StatusCode)
+
+	client.RestoreMockup()
+	assert.EqualValues(t, http.StatusInternalServerError, DeleteRepository("", "octocat", "old-repo").StatusCode)
+}
+
+func TestGetRepository(t *testing.T) {
+
+	respond := func(statusCode int, body string) {
+		client.RestoreMockup()
+		client.AddMockBehavior(client.Mock{
+			HttpMethod: http.MethodGet,
+			Url:        "https://api.github.com/repos/octocat/old-repo",
+			Response:   &http.Response{Body: ioutil.NopCloser(strings.NewReader(body)), StatusCode: statusCode},
+		})
+	}
+
+	respond(http.StatusOK, `{"id": 1296269, "full_name": "octocat/old-repo"}`)
+	repository, err := GetRepository("", "octocat", "old-repo")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1296269, repository.ID)
+
+	respond(http.StatusNotFound, "")
+	_, err = GetRepository("", "octocat", "old-repo")
+	assert.EqualValues(t, http.StatusNotFound, err.StatusCode)
+
+	respond(http.StatusOK, "{")
+	_, err = GetRepository("", "octocat", "old-repo")
+	assert.EqualValues(t, http.StatusInternalServerError, err.StatusCode)
+}
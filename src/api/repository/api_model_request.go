@@ -1,7 +1,148 @@
 package repository
 
+import (
+	"fmt"
+	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	PublicVisibility   = "public"
+	PrivateVisibility  = "private"
+	InternalVisibility = "internal"
+)
+
+const (
+	maxAccountName    = 39
+	maxRepositoryName = 100
+)
+
+var (
+	// accountNamePattern follows the GitHub rules for user and organization names.
+	accountNamePattern    = regexp.MustCompile(`^[A-Za-z0-9]+(-[A-Za-z0-9]+)*$`)
+	repositoryNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// IsAccountName tells whether name can be the login of a GitHub user or organization.
+func IsAccountName(name string) bool {
+
+	return len(name) <= maxAccountName && accountNamePattern.MatchString(name)
+}
+
+// IsRepositoryName tells whether name is usable as is for a GitHub repository.
+func IsRepositoryName(name string) bool {
+
+	return len(name) <= maxRepositoryName && repositoryNamePattern.MatchString(name) && name != "." && name != ".."
+}
+
+// templateNamePattern matches the names of the gitignore and license templates GitHub offers,
+// like "Go" or "apache-2.0".
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+
+// ApiRequest holds the options of a new repository. The optional flags are pointers so that an
+// omitted one gets the server side default instead of false.
 type ApiRequest struct {
 
 	Name string `json:"name"`
 	Description string `json:"description"`
+	// Org creates the repository in that organization instead of the account of the token owner.
+	Org string `json:"org,omitempty"`
+	Homepage string `json:"homepage,omitempty"`
+	// Private is kept for older clients, Visibility wins when both are given.
+	Private *bool `json:"private,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+	HasIssues *bool `json:"has_issues,omitempty"`
+	HasProjects *bool `json:"has_projects,omitempty"`
+	HasWiki *bool `json:"has_wiki,omitempty"`
+	IsTemplate bool `json:"is_template,omitempty"`
+	AutoInit bool `json:"auto_init,omitempty"`
+	GitignoreTemplate string `json:"gitignore_template,omitempty"`
+	LicenseTemplate string `json:"license_template,omitempty"`
+	AllowSquashMerge *bool `json:"allow_squash_merge,omitempty"`
+	AllowMergeCommit *bool `json:"allow_merge_commit,omitempty"`
+	AllowRebaseMerge *bool `json:"allow_rebase_merge,omitempty"`
+	AllowAutoMerge bool `json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge bool `json:"delete_branch_on_merge,omitempty"`
+}
+
+// Validate checks the options beyond the name, which the service validates itself.
+func (r *ApiRequest) Validate() errorApi.ApiError {
+
+	if homepage := strings.TrimSpace(r.Homepage); homepage != "" {
+		parsed, err := url.Parse(homepage)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errorApi.NewBadRequestError("invalid homepage, it must be an absolute http(s) url")
+		}
+	}
+
+	switch r.Visibility {
+	case "", PublicVisibility, PrivateVisibility:
+	case InternalVisibility:
+		if strings.TrimSpace(r.Org) == "" {
+			return errorApi.NewBadRequestError("internal visibility is only available for organization repositories")
+		}
+	default:
+		return errorApi.NewBadRequestError(fmt.Sprintf("invalid visibility %q, use %s, %s or %s",
+			r.Visibility, PublicVisibility, PrivateVisibility, InternalVisibility))
+	}
+
+	if r.Private != nil && r.Visibility != "" && *r.Private != (r.Visibility == PrivateVisibility) {
+		return errorApi.NewBadRequestError("private contradicts the requested visibility")
+	}
+
+	if r.GitignoreTemplate != "" && !templateNamePattern.MatchString(r.GitignoreTemplate) {
+		return errorApi.NewBadRequestError("invalid gitignore_template")
+	}
+
+	if r.LicenseTemplate != "" && !templateNamePattern.MatchString(r.LicenseTemplate) {
+		return errorApi.NewBadRequestError("invalid license_template")
+	}
+
+	if isFalse(r.AllowSquashMerge) && isFalse(r.AllowMergeCommit) && isFalse(r.AllowRebaseMerge) {
+		return errorApi.NewBadRequestError("at least one merge strategy must be allowed")
+	}
+
+	return nil
+}
+
+func isFalse(value *bool) bool {
+
+	return value != nil && !*value
+}
+
+// TemplateRequest generates a repository from the template repository TemplateOwner/TemplateRepo.
+// Owner is the user or organization the new repository belongs to, the token owner when empty.
+type TemplateRequest struct {
+
+	TemplateOwner string `json:"template_owner"`
+	TemplateRepo string `json:"template_repo"`
+	Owner string `json:"owner,omitempty"`
+	Name string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private bool `json:"private,omitempty"`
+	IncludeAllBranches bool `json:"include_all_branches,omitempty"`
+}
+
+func (r *TemplateRequest) Validate() errorApi.ApiError {
+
+	r.TemplateOwner = strings.TrimSpace(r.TemplateOwner)
+	r.TemplateRepo = strings.TrimSpace(r.TemplateRepo)
+	r.Owner = strings.TrimSpace(r.Owner)
+	r.Name = strings.TrimSpace(r.Name)
+
+	if r.Name == "" {
+		return errorApi.NewBadRequestError("invalid input name")
+	}
+
+	if !IsAccountName(r.TemplateOwner) || !IsRepositoryName(r.TemplateRepo) {
+		return errorApi.NewBadRequestError("invalid template repository, template_owner and template_repo are required")
+	}
+
+	if r.Owner != "" && !IsAccountName(r.Owner) {
+		return errorApi.NewBadRequestError("invalid owner")
+	}
+
+	return nil
 }
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestValidateAcceptsOptions(t *testing.T) {
+
+	private := true
+	request := ApiRequest{
+		Name:              "repo",
+		Homepage:          "https://example.com/docs",
+		Private:           &private,
+		Visibility:        PrivateVisibility,
+		GitignoreTemplate: "Go",
+		LicenseTemplate:   "apache-2.0",
+	}
+
+	assert.Nil(t, request.Validate())
+	assert.Nil(t, (&ApiRequest{Name: "repo", Org: "octo-org", Visibility: InternalVisibility}).Validate())
+}
+
+func TestValidateRejectsOptions(t *testing.T) {
+
+	public := false
+	disabled := false
+
+	for message, request := range map[string]ApiRequest{
+		"invalid homepage, it must be an absolute http(s) url":                {Homepage: "example.com"},
+		"invalid visibility \"secret\", use public, private or internal":      {Visibility: "secret"},
+		"internal visibility is only available for organization repositories": {Visibility: InternalVisibility},
+		"private contradicts the requested visibility":                        {Private: &public, Visibility: PrivateVisibility},
+		"invalid gitignore_template":                                          {GitignoreTemplate: "../Go"},
+		"invalid license_template":                                            {LicenseTemplate: "mit license"},
+		"at least one merge strategy must be allowed": {
+			AllowSquashMerge: &disabled, AllowMergeCommit: &disabled, AllowRebaseMerge: &disabled},
+	} {
+		err := request.Validate()
+		assert.NotNil(t, err, message)
+		assert.EqualValues(t, http.StatusBadRequest, err.Status())
+		assert.EqualValues(t, message, err.Message())
+	}
+}
+
+func TestTemplateRequestValidate(t *testing.T) {
+
+	request := TemplateRequest{TemplateOwner: " octo-org ", TemplateRepo: "golden.template", Name: " new-service "}
+	assert.Nil(t, request.Validate())
+	assert.EqualValues(t, "octo-org", request.TemplateOwner)
+	assert.EqualValues(t, "new-service", request.Name)
+
+	for message, invalid := range map[string]TemplateRequest{
+		"invalid input name": {TemplateOwner: "octo-org", TemplateRepo: "template"},
+		"invalid template repository, template_owner and template_repo are required": {Name: "new-service", TemplateRepo: "template"},
+		"invalid owner": {TemplateOwner: "octo-org", TemplateRepo: "template", Name: "new-service", Owner: "octo/org"},
+	} {
+		err := invalid.Validate()
+		assert.NotNil(t, err, message)
+		assert.EqualValues(t, message, err.Message())
+	}
+
+	assert.NotNil(t, (&TemplateRequest{TemplateOwner: "octo-org", TemplateRepo: "..", Name: "new-service"}).Validate())
+}
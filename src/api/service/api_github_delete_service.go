@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/leandrotula/golangmicroservice/src/api/errorApi"
+	"github.com/leandrotula/golangmicroservice/src/api/provider/environment"
+	"github.com/leandrotula/golangmicroservice/src/api/provider/github_provider"
+	"github.com/leandrotula/golangmicroservice/src/api/repository"
+)
+
+type deleteRepoInterface interface {
+
+	DeleteRepo(owner string, name string) errorApi.ApiError
+	RepositoryId(owner string, name string) (int64, errorApi.ApiError)
+}
+
+type deleteRepoImpl struct {}
+
+var (
+	DeleteRepoOperation deleteRepoInterface
+)
+
+func init() {
+	DeleteRepoOperation = &deleteRepoImpl{}
+}
+
+func (op *deleteRepoImpl) DeleteRepo(owner string, name string) errorApi.ApiError {
+
+	if !repository.IsAccountName(owner) || !repository.IsRepositoryName(name) {
+		return errorApi.NewBadRequestError("invalid repository, owner and name are required")
+	}
+
+	authorizationHeader := environment.RetrieveAuthorizationHeader()
+	if errorResponse := github_provider.DeleteRepository(authorizationHeader, owner, name); errorResponse != nil {
+		return errorApi.NewApiError(errorResponse.Message, errorResponse.StatusCode)
+	}
+
+	return nil
+}
+
+// RepositoryId returns the id GitHub currently has for owner/name, which changes when the
+// repository is deleted and created again under the same name.
+func (op *deleteRepoImpl) RepositoryId(owner string, name string) (int64, errorApi.ApiError) {
+
+	if !repository.IsAccountName(owner) || !repository.IsRepositoryName(name) {
+		return 0, errorApi.NewBadRequestError("invalid repository, owner and name are required")
+	}
+
+	authorizationHeader := environment.RetrieveAuthorizationHeader()
+	found, errorResponse := github_provider.GetRepository(authorizationHeader, owner, name)
+	if errorResponse != nil {
+		return 0, errorApi.NewApiError(errorResponse.Message, errorResponse.StatusCode)
+	}
+
+	return int64(found.ID), nil
+}
@@ -15,6 +15,7 @@ type createRepoInterface interface {
 
 	CreateRepo(request *repository.ApiRequest) (*repository.ApiResponse, errorApi.ApiError)
 	CreateRepos(request []repository.ApiRequest) (repository.CreateReposResponse, errorApi.ApiError)
+	CreateRepoFromTemplate(request *repository.TemplateRequest) (*repository.ApiResponse, errorApi.ApiError)
 }
 
 type createRepoImpl struct {}
@@ -34,10 +35,43 @@ func (op *createRepoImpl) CreateRepo(request *repository.ApiRequest) (*repositor
 		return apiResponse, apiError
 	}
 
-	req := github.CreateRepositoryRequestGithub{Name: inputName, Description: request.Description}
+	req := githubRequest(inputName, request)
+
+	response, errorResponse, genericError := postRepository(strings.TrimSpace(request.Org), req)
+
+	if errorResponse != nil {
+		return nil, errorApi.NewApiError(errorResponse.Message, errorResponse.StatusCode)
+	}
+
+	if genericError != nil {
+
+		return nil, errorApi.NewApiError(genericError.Message, http.StatusBadRequest)
+	}
+
+	return &repository.ApiResponse{
+		ID:       response.ID,
+		Name:     response.Name,
+		FullName: response.FullName,
+	}, nil
+}
+
+func (op *createRepoImpl) CreateRepoFromTemplate(request *repository.TemplateRequest) (*repository.ApiResponse, errorApi.ApiError) {
+
+	if apiError := request.Validate(); apiError != nil {
+		return nil, apiError
+	}
+
+	req := github.GenerateRepositoryRequestGithub{
+		Owner:              request.Owner,
+		Name:               request.Name,
+		Description:        request.Description,
+		IncludeAllBranches: request.IncludeAllBranches,
+		Private:            request.Private,
+	}
 
 	authorizationHeader := environment.RetrieveAuthorizationHeader()
-	response, errorResponse, genericError := github_provider.CreatePostRepository(authorizationHeader, req)
+	response, errorResponse, genericError := github_provider.GenerateRepository(authorizationHeader,
+		request.TemplateOwner, request.TemplateRepo, req)
 
 	if errorResponse != nil {
 		return nil, errorApi.NewApiError(errorResponse.Message, errorResponse.StatusCode)
@@ -60,9 +94,67 @@ func validate(request *repository.ApiRequest) (string, *repository.ApiResponse,
 	if inputName == "" {
 		return "", nil, errorApi.NewBadRequestError("invalid input name"), true
 	}
+	if org := strings.TrimSpace(request.Org); org != "" && !repository.IsAccountName(org) {
+		return "", nil, errorApi.NewBadRequestError("invalid organization name"), true
+	}
+	if apiError := request.Validate(); apiError != nil {
+		return "", nil, apiError, true
+	}
 	return inputName, nil, nil, false
 }
 
+// githubRequest fills in the options the request leaves out: a public repository with issues,
+// projects, the wiki and every merge strategy enabled, like GitHub itself does.
+func githubRequest(name string, request *repository.ApiRequest) github.CreateRepositoryRequestGithub {
+
+	req := github.CreateRepositoryRequestGithub{
+		Name:                name,
+		Description:         request.Description,
+		Homepage:            strings.TrimSpace(request.Homepage),
+		Private:             valueOr(request.Private, false),
+		Visibility:          request.Visibility,
+		HasIssues:           valueOr(request.HasIssues, true),
+		HasProjects:         valueOr(request.HasProjects, true),
+		HasWiki:             valueOr(request.HasWiki, true),
+		IsTemplate:          request.IsTemplate,
+		AutoInit:            request.AutoInit,
+		GitignoreTemplate:   request.GitignoreTemplate,
+		LicenseTemplate:     request.LicenseTemplate,
+		AllowSquashMerge:    valueOr(request.AllowSquashMerge, true),
+		AllowMergeCommit:    valueOr(request.AllowMergeCommit, true),
+		AllowRebaseMerge:    valueOr(request.AllowRebaseMerge, true),
+		AllowAutoMerge:      request.AllowAutoMerge,
+		DeleteBranchOnMerge: request.DeleteBranchOnMerge,
+	}
+
+	if request.Visibility != "" {
+		req.Private = request.Visibility == repository.PrivateVisibility
+	}
+
+	return req
+}
+
+func valueOr(value *bool, fallback bool) bool {
+
+	if value == nil {
+		return fallback
+	}
+
+	return *value
+}
+
+// postRepository creates the repository in org, or for the token owner when there is none.
+func postRepository(org string, request github.CreateRepositoryRequestGithub) (*github.CreateRepositoryResponseGithub,
+	*github.ErrorResponseGithub, *github.UnprocessableEntityResponseGithub) {
+
+	authorizationHeader := environment.RetrieveAuthorizationHeader()
+	if org == "" {
+		return github_provider.CreatePostRepository(authorizationHeader, request)
+	}
+
+	return github_provider.CreateOrgRepository(authorizationHeader, org, request)
+}
+
 func (op *createRepoImpl) CreateRepos(requests []repository.ApiRequest) (repository.CreateReposResponse, errorApi.ApiError) {
 
 	input := make(chan repository.CreateRepositoriesResponse)
@@ -131,11 +223,9 @@ func (op *createRepoImpl) createSingleRepo(providedRequest repository.ApiRequest
 		return
 	}
 
-	req := github.CreateRepositoryRequestGithub{Name: providedRequest.Name,
-		Description: providedRequest.Description}
+	req := githubRequest(strings.TrimSpace(providedRequest.Name), &providedRequest)
 
-	authorizationHeader := environment.RetrieveAuthorizationHeader()
-	response, errorResponse, genericError := github_provider.CreatePostRepository(authorizationHeader, req)
+	response, errorResponse, genericError := postRepository(strings.TrimSpace(providedRequest.Org), req)
 
 	if errorResponse != nil {
 		output <- repository.CreateRepositoriesResponse{
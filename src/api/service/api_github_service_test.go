@@ -404,3 +404,104 @@ func TestCreateReposWithError(t *testing.T) {
 	assert.NotNil(t, response.Results[0].Error)
 
 }
+
+func TestCreateRepoInOrg(t *testing.T) {
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		Url:        "https://api.github.com/orgs/octo-org/repos",
+		HttpMethod: http.MethodPost,
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id":42,"name":"test-repo","full_name":"octo-org/test-repo"}`)),
+			StatusCode: http.StatusCreated,
+		},
+	})
+
+	response, err := CreateRepoOperation.CreateRepo(&repository.ApiRequest{Name: "test-repo", Org: " octo-org "})
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, "octo-org/test-repo", response.FullName)
+}
+
+func TestCreateRepoInvalidOrg(t *testing.T) {
+
+	for _, org := range []string{"-octo", "octo--org", "octo/org", strings.Repeat("o", 40)} {
+
+		response, err := CreateRepoOperation.CreateRepo(&repository.ApiRequest{Name: "test-repo", Org: org})
+
+		assert.Nil(t, response)
+		assert.EqualValues(t, http.StatusBadRequest, err.Status())
+		assert.EqualValues(t, "invalid organization name", err.Message())
+	}
+}
+
+func TestGithubRequestDefaults(t *testing.T) {
+
+	req := githubRequest("repo", &repository.ApiRequest{Name: "repo"})
+
+	assert.False(t, req.Private)
+	assert.Empty(t, req.Visibility)
+	assert.True(t, req.HasIssues)
+	assert.True(t, req.HasProjects)
+	assert.True(t, req.HasWiki)
+	assert.True(t, req.AllowSquashMerge)
+	assert.True(t, req.AllowMergeCommit)
+	assert.True(t, req.AllowRebaseMerge)
+	assert.False(t, req.AutoInit)
+}
+
+func TestGithubRequestPassesOptions(t *testing.T) {
+
+	disabled := false
+	req := githubRequest("repo", &repository.ApiRequest{
+		Name:              "repo",
+		Visibility:        repository.PrivateVisibility,
+		HasWiki:           &disabled,
+		AllowMergeCommit:  &disabled,
+		AutoInit:          true,
+		GitignoreTemplate: "Go",
+		LicenseTemplate:   "mit",
+		IsTemplate:        true,
+	})
+
+	assert.True(t, req.Private)
+	assert.EqualValues(t, repository.PrivateVisibility, req.Visibility)
+	assert.False(t, req.HasWiki)
+	assert.False(t, req.AllowMergeCommit)
+	assert.True(t, req.AllowSquashMerge)
+	assert.True(t, req.AutoInit)
+	assert.True(t, req.IsTemplate)
+	assert.EqualValues(t, "Go", req.GitignoreTemplate)
+	assert.EqualValues(t, "mit", req.LicenseTemplate)
+}
+
+func TestCreateRepoRejectsInvalidOptions(t *testing.T) {
+
+	response, err := CreateRepoOperation.CreateRepo(&repository.ApiRequest{Name: "repo", Visibility: "secret"})
+
+	assert.Nil(t, response)
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+}
+
+func TestCreateRepoFromTemplate(t *testing.T) {
+
+	client.RestoreMockup()
+	client.AddMockBehavior(client.Mock{
+		Url:        "https://api.github.com/repos/octo-org/golden-template/generate",
+		HttpMethod: http.MethodPost,
+		Response: &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message":"Repository creation failed.","errors":[{"resource":"Repository","code":"custom","field":"name","message":"name already exists on this account"}]}`)),
+			StatusCode: http.StatusUnprocessableEntity,
+		},
+	})
+
+	request := &repository.TemplateRequest{TemplateOwner: "octo-org", TemplateRepo: "golden-template", Name: "new-service"}
+	response, err := CreateRepoOperation.CreateRepoFromTemplate(request)
+
+	assert.Nil(t, response)
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+	assert.EqualValues(t, "Repository creation failed.", err.Message())
+
+	_, err = CreateRepoOperation.CreateRepoFromTemplate(&repository.TemplateRequest{Name: "new-service"})
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+}
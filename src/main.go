@@ -1,8 +0,0 @@
-package main
-
-import "github.com/leandrotula/golangmicroservice/src/api/app"
-
-func main() {
-
-	app.StartApp()
-}
@@ -4,4 +4,11 @@ type ResponseError struct {
 
 	Message string `json:"message"`
 	Code int `json:"code"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+
+	Field string `json:"field"`
+	Message string `json:"message"`
 }